@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+)
+
+func (api *api) absencesList(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	absenceList, err := api.store.Absences.List(r.Context(), userId, r.URL.Query().Get("kind"), from, to)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"absences": absenceList,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) absencesRegister(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body absences.RegisterAbsenceInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	absence, err := api.store.Absences.Register(r.Context(), userId, body)
+	if err != nil {
+		switch err {
+		case absences.ErrAbsenceOverlaps:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"absence": absence,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) absencesDelete(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Absences.Delete(r.Context(), id, userId); err != nil {
+		switch err {
+		case absences.ErrAbsenceNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
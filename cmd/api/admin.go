@@ -1,10 +1,21 @@
 package main
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/closing_days"
+	"github.com/anvidev/project-time-tracker/internal/store/locks"
+	"github.com/anvidev/project-time-tracker/internal/store/projects"
+	"github.com/anvidev/project-time-tracker/internal/store/rates"
+	"github.com/anvidev/project-time-tracker/internal/store/teams"
 	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/store/timesheets"
+	"github.com/anvidev/project-time-tracker/internal/store/vacation"
 )
 
 func (api *api) adminTimeEntries(w http.ResponseWriter, r *http.Request) {
@@ -32,15 +43,41 @@ func (api *api) adminTimeEntries(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var timeSpent time.Duration
+	table, err := api.store.Rates.Table(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
 
-	for _, entry := range entries {
+	var (
+		timeSpent    time.Duration
+		billableTime time.Duration
+		revenue      float64
+	)
+
+	for i, entry := range entries {
 		timeSpent += entry.Duration.Duration
+
+		if !entry.Billable {
+			continue
+		}
+		billableTime += entry.Duration.Duration
+
+		rate, ok := table.Lookup(entry.UserId, entry.CategoryId, entry.Date)
+		if !ok {
+			continue
+		}
+		amount := roundCurrency(rate * entry.Duration.Hours())
+		entries[i].Rate = &rate
+		entries[i].Revenue = &amount
+		revenue += amount
 	}
 
 	response := map[string]any{
-		"timeSpent": timeSpent.String(),
-		"entries":   entries,
+		"timeSpent":    timeSpent.String(),
+		"billableTime": billableTime.String(),
+		"revenue":      roundCurrency(revenue),
+		"entries":      entries,
 	}
 
 	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
@@ -82,3 +119,1040 @@ func (api *api) adminCategories(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (api *api) adminUserVacation(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	date, err := parseOptionalDate(r, "date", time.Now())
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	balance, err := api.store.Vacation.Balance(r.Context(), userId, date)
+	if err != nil {
+		switch err {
+		case vacation.ErrUserNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"vacation": balance,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminUpdateUserVacation(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body vacation.UpdateAllowanceInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	allowance, err := api.store.Vacation.SetAllowance(r.Context(), userId, year, body)
+	if err != nil {
+		switch err {
+		case vacation.ErrUserNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"allowance": allowance,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminClosingDays(w http.ResponseWriter, r *http.Request) {
+	year := time.Now().Year()
+	if r.URL.Query().Get("year") != "" {
+		parsed, err := strconv.Atoi(r.URL.Query().Get("year"))
+		if err != nil {
+			api.badRequestError(w, r, err)
+			return
+		}
+		year = parsed
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	days, err := api.store.ClosingDays.List(r.Context(), from, to)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"closingDays": days,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateClosingDay(w http.ResponseWriter, r *http.Request) {
+	var body closing_days.CreateClosingDayInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	day, err := api.store.ClosingDays.Create(r.Context(), body)
+	if err != nil {
+		switch err {
+		case closing_days.ErrDuplicateDate:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"closingDay": day,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminDeleteClosingDay(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.ClosingDays.Delete(r.Context(), id); err != nil {
+		switch err {
+		case closing_days.ErrClosingDayNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminTimesheets(w http.ResponseWriter, r *http.Request) {
+	filters := timesheets.ListFilters{
+		Status: r.URL.Query().Get("status"),
+	}
+
+	if r.URL.Query().Get("userId") != "" {
+		userId, err := strconv.ParseInt(r.URL.Query().Get("userId"), 10, 64)
+		if err != nil {
+			api.badRequestError(w, r, err)
+			return
+		}
+		filters.UserId = &userId
+	}
+
+	list, err := api.store.Timesheets.List(r.Context(), filters)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"timesheets": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminApproveTimesheet(w http.ResponseWriter, r *http.Request) {
+	reviewerId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timesheet, err := api.store.Timesheets.Approve(r.Context(), reviewerId, id)
+	if err != nil {
+		switch err {
+		case timesheets.ErrTimesheetNotFound:
+			api.notFoundError(w, r, err)
+		case timesheets.ErrInvalidTransition:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timesheet": timesheet,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminRejectTimesheet(w http.ResponseWriter, r *http.Request) {
+	reviewerId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body timesheets.RejectTimesheetInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timesheet, err := api.store.Timesheets.Reject(r.Context(), reviewerId, id, body.Comment)
+	if err != nil {
+		switch err {
+		case timesheets.ErrTimesheetNotFound:
+			api.notFoundError(w, r, err)
+		case timesheets.ErrInvalidTransition:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timesheet": timesheet,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminLocks(w http.ResponseWriter, r *http.Request) {
+	includeUnlocked := r.URL.Query().Get("all") == "true"
+
+	list, err := api.store.Locks.List(r.Context(), includeUnlocked)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"locks": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateLock(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body locks.CreateLockInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	lock, err := api.store.Locks.Create(r.Context(), userId, body)
+	if err != nil {
+		switch err {
+		case locks.ErrInvalidRange:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"lock": lock,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminUnlock(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	lock, err := api.store.Locks.Unlock(r.Context(), userId, id)
+	if err != nil {
+		switch err {
+		case locks.ErrLockNotFound:
+			api.notFoundError(w, r, err)
+		case locks.ErrAlreadyUnlocked:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"lock": lock,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminAudit(w http.ResponseWriter, r *http.Request) {
+	var filters audit.Filters
+
+	if err := filters.Parse(r); err != nil {
+		switch err {
+		case
+			audit.ErrInvalidUserId,
+			audit.ErrInvalidEntityId,
+			audit.ErrInvalidFromDate,
+			audit.ErrInvalidToDate,
+			audit.ErrFromDateAfterToDate,
+			audit.ErrInvalidLimit:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	entries, err := api.store.Audit.List(r.Context(), filters)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"entries": entries,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// roundCurrency rounds an amount to two decimals.
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (api *api) adminCategoryTreeTotals(w http.ResponseWriter, r *http.Request) {
+	var filters categories.TotalsFilters
+
+	if from := r.URL.Query().Get("from"); from != "" {
+		if _, err := time.Parse(time.DateOnly, from); err != nil {
+			api.badRequestError(w, r, time_entries.ErrInvalidFromDate)
+			return
+		}
+		filters.From = &from
+	}
+
+	if to := r.URL.Query().Get("to"); to != "" {
+		if _, err := time.Parse(time.DateOnly, to); err != nil {
+			api.badRequestError(w, r, time_entries.ErrInvalidToDate)
+			return
+		}
+		filters.To = &to
+	}
+
+	if filters.From != nil && filters.To != nil && *filters.From > *filters.To {
+		api.badRequestError(w, r, time_entries.ErrFromDateAfterToDate)
+		return
+	}
+
+	if r.URL.Query().Get("userId") != "" {
+		userId, err := strconv.ParseInt(r.URL.Query().Get("userId"), 10, 64)
+		if err != nil {
+			api.badRequestError(w, r, err)
+			return
+		}
+		filters.UserId = &userId
+	}
+
+	tree, err := api.store.Categories.TreeTotals(r.Context(), filters)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"categories": tree,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminSetCategoryBillable(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.SetBillableInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.SetBillable(r.Context(), id, body.IsBillable); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminRates(w http.ResponseWriter, r *http.Request) {
+	list, err := api.store.Rates.List(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"rates": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateRate(w http.ResponseWriter, r *http.Request) {
+	var body rates.CreateRateInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	rate, err := api.store.Rates.Create(r.Context(), body)
+	if err != nil {
+		switch err {
+		case rates.ErrDuplicateRate:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"rate": rate,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminDeleteRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Rates.Delete(r.Context(), id); err != nil {
+		switch err {
+		case rates.ErrRateNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminSetCategoryProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.SetProjectInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.SetProject(r.Context(), id, body.ProjectId); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrNotRootCategory, categories.ErrProjectNotFound:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminSetCategoryBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.SetBudgetInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.SetBudget(r.Context(), id, body.Budget); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrInvalidBudget:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminSetCategoryDefaultFollowed(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.SetDefaultFollowedInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.SetDefaultFollowed(r.Context(), id, body.IsDefaultFollowed); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminMoveCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.MoveCategoryInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.Move(r.Context(), id, body.ParentId); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrCategoryCycle:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminMergeCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.MergeCategoryInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.Merge(r.Context(), id, body.TargetId); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrCategoryCycle, categories.ErrMergeIntoSelf:
+			api.badRequestError(w, r, err)
+		case locks.ErrTimesheetSubmitted:
+			api.conflictError(w, r, err)
+		case locks.ErrPeriodLocked:
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminDeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Categories.Delete(r.Context(), id); err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrCategoryNotEmpty:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminCategoryAccess(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	access, err := api.store.Categories.Access(r.Context(), id)
+	if err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"access": access,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminSetCategoryAccess(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body categories.SetAccessInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	access, err := api.store.Categories.SetAccess(r.Context(), id, body)
+	if err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case categories.ErrUnknownAccessId:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"access": access,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminTeams(w http.ResponseWriter, r *http.Request) {
+	list, err := api.store.Teams.List(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"teams": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateTeam(w http.ResponseWriter, r *http.Request) {
+	var body teams.CreateTeamInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	team, err := api.store.Teams.Create(r.Context(), body)
+	if err != nil {
+		switch err {
+		case teams.ErrDuplicateName:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"team": team,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminDeleteTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.Teams.Delete(r.Context(), id); err != nil {
+		switch err {
+		case teams.ErrTeamNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *api) adminSetTeamMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body teams.SetMembersInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	team, err := api.store.Teams.SetMembers(r.Context(), id, body.UserIds)
+	if err != nil {
+		switch err {
+		case teams.ErrTeamNotFound:
+			api.notFoundError(w, r, err)
+		case teams.ErrUnknownMemberId:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"team": team,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminClients(w http.ResponseWriter, r *http.Request) {
+	clients, err := api.store.Projects.ListClients(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"clients": clients,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateClient(w http.ResponseWriter, r *http.Request) {
+	var body projects.CreateClientInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	client, err := api.store.Projects.CreateClient(r.Context(), body)
+	if err != nil {
+		switch err {
+		case projects.ErrDuplicateCode:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"client": client,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminUpdateClient(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body projects.UpdateClientInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	client, err := api.store.Projects.UpdateClient(r.Context(), id, body)
+	if err != nil {
+		switch err {
+		case projects.ErrClientNotFound:
+			api.notFoundError(w, r, err)
+		case projects.ErrDuplicateCode:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"client": client,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminProjects(w http.ResponseWriter, r *http.Request) {
+	var clientId *int64
+
+	if r.URL.Query().Get("clientId") != "" {
+		id, err := strconv.ParseInt(r.URL.Query().Get("clientId"), 10, 64)
+		if err != nil {
+			api.badRequestError(w, r, err)
+			return
+		}
+		clientId = &id
+	}
+
+	list, err := api.store.Projects.ListProjects(r.Context(), clientId)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"projects": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateProject(w http.ResponseWriter, r *http.Request) {
+	var body projects.CreateProjectInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	project, err := api.store.Projects.CreateProject(r.Context(), body)
+	if err != nil {
+		switch err {
+		case projects.ErrClientNotFound, projects.ErrOwnerNotFound:
+			api.badRequestError(w, r, err)
+		case projects.ErrDuplicateCode:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"project": project,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminUpdateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body projects.UpdateProjectInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	project, err := api.store.Projects.UpdateProject(r.Context(), id, body)
+	if err != nil {
+		switch err {
+		case projects.ErrProjectNotFound:
+			api.notFoundError(w, r, err)
+		case projects.ErrClientNotFound, projects.ErrOwnerNotFound:
+			api.badRequestError(w, r, err)
+		case projects.ErrDuplicateCode:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"project": project,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/anvidev/apiduck"
 	"github.com/anvidev/goenv"
 	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/holidays"
 	"github.com/anvidev/project-time-tracker/internal/mailer"
 	"github.com/anvidev/project-time-tracker/internal/store"
 	"github.com/go-chi/chi/v5"
@@ -30,6 +31,8 @@ func (api *api) handler() http.Handler {
 
 	r.Route("/v1", func(r chi.Router) {
 		r.Get("/docs", api.docs.Serve)
+		r.Get("/holidays/{year}", api.holidaysYear)
+		r.Get("/ical/{token}.ics", api.icalFeed) // ?timed=true
 
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/register", api.authRegister)
@@ -49,26 +52,114 @@ func (api *api) handler() http.Handler {
 				r.Get("/all", api.entriesCategoriesTree)
 				r.Put("/{id}/follow", api.entriesFollowCategory)
 				r.Put("/{id}/unfollow", api.entriesUnfollowCategory)
+				r.Put("/{id}/follow/subtree", api.entriesFollowCategorySubtree)
+				r.Put("/{id}/unfollow/subtree", api.entriesUnfollowCategorySubtree)
 			})
 
 			r.Route("/time_entries", func(r chi.Router) {
 				r.Post("/", api.entriesRegisterTime)
+				r.Post("/bulk", api.entriesRegisterTimeBulk)
+				r.Post("/suggestions/calendar", api.suggestionsFromCalendar) // .ics body or ?file, ?from&to
+				r.Post("/suggestions/git", api.suggestionsFromGit)           // git log body, ?repository&branch&author&from&to
 				r.Put("/{id}", api.entriesUpdateTime)
 				r.Delete("/{id}", api.entriesDelete)
+				r.Get("/trash", api.entriesTrash)
+				r.Get("/export", api.entriesExport) // ?format=csv|xlsx&columns&query&categoryId&fromDate&toDate
+				r.Post("/{id}/restore", api.entriesRestore)
 				r.Get("/day/{date}", api.entriesSummaryDay)           // date: YYYY-MM-DD
 				r.Get("/month/{year-month}", api.entriesSummaryMonth) // month: YYYY-MM
+				r.Get("/month/{year-month}/pdf", api.entriesMonthPDF) // ?report=timesheet|categories&categoryId
 			})
 
 			r.Route("/hours", func(r chi.Router) {
 				r.Get("/", api.hoursAll)
 				r.Put("/", api.update)
 			})
+
+			r.Route("/absences", func(r chi.Router) {
+				r.Get("/", api.absencesList) // ?from=YYYY-MM-DD&to=YYYY-MM-DD
+				r.Post("/", api.absencesRegister)
+				r.Delete("/{id}", api.absencesDelete)
+			})
+
+			r.Get("/vacation", api.vacationBalance) // ?date=YYYY-MM-DD
+
+			r.Route("/suggestion_rules", func(r chi.Router) {
+				r.Get("/", api.suggestionRulesList) // ?kind=calendar|git
+				r.Post("/", api.suggestionRulesCreate)
+				r.Delete("/{id}", api.suggestionRulesDelete)
+			})
+
+			r.Route("/calendar", func(r chi.Router) {
+				r.Get("/", api.calendarFeed)
+				r.Post("/", api.calendarResetFeed)
+				r.Delete("/", api.calendarDeleteFeed)
+			})
+
+			r.Route("/timesheets", func(r chi.Router) {
+				r.Get("/", api.timesheetsList)
+				r.Post("/", api.timesheetsSubmit)
+				r.Put("/{id}/withdraw", api.timesheetsWithdraw)
+			})
 		})
 
 		r.Route("/admin", func(r chi.Router) {
-			r.Get("/time_entries", api.adminTimeEntries)
+			r.Use(api.bearerAuthorization)
+			r.Use(api.adminAuthorization)
+			r.Get("/time_entries", api.adminTimeEntries)                            // ?query&categoryId&projectId&clientId&userId&fromDate&toDate
+			r.Get("/time_entries/export", api.adminExportTimeEntries)               // same filters as time_entries, ?format=csv|xlsx&columns
+			r.Post("/time_entries/import", api.adminImportTimeEntries)              // file body, ?format=csv|toggl|clockify|harvest&dryRun=true
+			r.Post("/time_entries/import/mappings", api.adminProposeImportMappings) // file body, ?format=toggl|clockify|harvest
+			r.Get("/import_mappings/{source}", api.adminImportMappings)
+			r.Put("/import_mappings/{source}", api.adminSaveImportMappings)
+			r.Delete("/import_mappings/{source}/{id}", api.adminDeleteImportMapping)
+			r.Get("/reports/summary", api.adminReportsSummary)         // same filters as time_entries, ?groupBy=user,category,rootCategory,week,month
+			r.Get("/reports/utilization", api.adminReportsUtilization) // ?from&to&period=total|week|month&userId&format=json|csv|xlsx
+			r.Route("/report_subscriptions", func(r chi.Router) {
+				r.Get("/", api.adminReportSubscriptions)
+				r.Post("/", api.adminCreateReportSubscription)
+				r.Put("/{id}", api.adminUpdateReportSubscription)
+				r.Delete("/{id}", api.adminDeleteReportSubscription)
+				r.Post("/{id}/send", api.adminSendReportSubscription)
+			})
 			r.Get("/users", api.adminUsers)
+			r.Get("/users/{id}/vacation", api.adminUserVacation)                            // ?date=YYYY-MM-DD
+			r.Put("/users/{id}/vacation/{year}", api.adminUpdateUserVacation)               // year: start year of the holiday year
+			r.Get("/users/{id}/time_entries/month/{year-month}/pdf", api.adminUserMonthPDF) // ?report=timesheet|categories&categoryId
 			r.Get("/categories", api.adminCategories)
+			r.Get("/categories/tree-totals", api.adminCategoryTreeTotals) // ?from&to&userId
+			r.Put("/categories/{id}/billable", api.adminSetCategoryBillable)
+			r.Put("/categories/{id}/project", api.adminSetCategoryProject)
+			r.Put("/categories/{id}/budget", api.adminSetCategoryBudget)
+			r.Put("/categories/{id}/default_followed", api.adminSetCategoryDefaultFollowed)
+			r.Put("/categories/{id}/move", api.adminMoveCategory)
+			r.Post("/categories/{id}/merge", api.adminMergeCategory)
+			r.Delete("/categories/{id}", api.adminDeleteCategory)
+			r.Get("/categories/{id}/access", api.adminCategoryAccess)
+			r.Put("/categories/{id}/access", api.adminSetCategoryAccess)
+			r.Get("/teams", api.adminTeams)
+			r.Post("/teams", api.adminCreateTeam)
+			r.Delete("/teams/{id}", api.adminDeleteTeam)
+			r.Put("/teams/{id}/members", api.adminSetTeamMembers)
+			r.Get("/clients", api.adminClients)
+			r.Post("/clients", api.adminCreateClient)
+			r.Put("/clients/{id}", api.adminUpdateClient)
+			r.Get("/projects", api.adminProjects) // ?clientId=1
+			r.Post("/projects", api.adminCreateProject)
+			r.Put("/projects/{id}", api.adminUpdateProject)
+			r.Get("/rates", api.adminRates)
+			r.Post("/rates", api.adminCreateRate)
+			r.Delete("/rates/{id}", api.adminDeleteRate)
+			r.Get("/closing_days", api.adminClosingDays) // ?year=YYYY
+			r.Post("/closing_days", api.adminCreateClosingDay)
+			r.Delete("/closing_days/{id}", api.adminDeleteClosingDay)
+			r.Get("/timesheets", api.adminTimesheets) // ?status=submitted&userId=1
+			r.Put("/timesheets/{id}/approve", api.adminApproveTimesheet)
+			r.Put("/timesheets/{id}/reject", api.adminRejectTimesheet)
+			r.Get("/locks", api.adminLocks) // ?all=true includes unlocked periods
+			r.Post("/locks", api.adminCreateLock)
+			r.Put("/locks/{id}/unlock", api.adminUnlock)
+			r.Get("/audit", api.adminAudit) // ?userId&entity&entityId&fromDate&toDate&limit
 		})
 
 	})
@@ -83,6 +174,8 @@ type api struct {
 	docs   *apiduck.Documentation
 	mails  mailer.Mailer
 
+	holidays holidays.RuleSet
+
 	cronInitialized bool
 	cron            gocron.Scheduler
 }
@@ -147,13 +240,19 @@ func NewApiContext(ctx context.Context) (*api, error) {
 		cronInitialized = true
 	}
 
+	holidayRules, err := holidays.Lookup(config.Holidays.Country)
+	if err != nil {
+		logger.Error("holiday rules initialization failed", "country", config.Holidays.Country, "error", err)
+		return nil, err
+	}
+
 	db, err := database.NewContext(ctx, config.Database.URL, config.Database.Token)
 	if err != nil {
 		logger.Error("database connection failed", "error", err)
 		return nil, err
 	}
 
-	store := store.NewStore(db)
+	store := store.NewStore(db, holidayRules)
 
 	api := &api{
 		logger: logger,
@@ -162,6 +261,8 @@ func NewApiContext(ctx context.Context) (*api, error) {
 		docs:   docs,
 		mails:  mails,
 
+		holidays: holidayRules,
+
 		cronInitialized: cronInitialized,
 		cron:            cron,
 	}
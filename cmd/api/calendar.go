@@ -0,0 +1,240 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/ical"
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+	"github.com/anvidev/project-time-tracker/internal/store/calendar_feeds"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+)
+
+var absenceKindNames = map[string]string{
+	absences.KindVacation:  "Ferie",
+	absences.KindSick:      "Sygdom",
+	absences.KindChildSick: "Barns sygedag",
+	absences.KindLeave:     "Orlov",
+	absences.KindOther:     "Fravær",
+}
+
+func (api *api) calendarFeedResponse(feed *calendar_feeds.Feed) map[string]any {
+	url := fmt.Sprintf("%s/v1/ical/%s.ics", strings.TrimSuffix(api.config.Server.PublicURL, "/"), feed.Token)
+
+	return map[string]any{
+		"feed":     feed,
+		"url":      url,
+		"timedUrl": url + "?timed=true",
+	}
+}
+
+func (api *api) calendarFeed(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	feed, err := api.store.CalendarFeeds.Get(r.Context(), userId)
+	if err != nil {
+		switch err {
+		case calendar_feeds.ErrFeedNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, api.calendarFeedResponse(feed)); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// calendarResetFeed creates the calendar feed of the user, or replaces its
+// token when the url has been shared by mistake.
+func (api *api) calendarResetFeed(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	feed, err := api.store.CalendarFeeds.Reset(r.Context(), userId)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, api.calendarFeedResponse(feed)); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) calendarDeleteFeed(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	if err := api.store.CalendarFeeds.Delete(r.Context(), userId); err != nil {
+		switch err {
+		case calendar_feeds.ErrFeedNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// icalFeed serves the time entries and absences of the user owning the
+// token as an iCalendar feed. Events are all-day, or with ?timed=true laid
+// out one after another from the configured start of the day.
+func (api *api) icalFeed(w http.ResponseWriter, r *http.Request) {
+	userId, err := api.store.CalendarFeeds.UserId(r.Context(), r.PathValue("token"))
+	if err != nil {
+		switch err {
+		case calendar_feeds.ErrFeedNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	user, err := api.store.Users.GetById(r.Context(), userId)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	if !user.IsActive {
+		api.notFoundError(w, r, calendar_feeds.ErrFeedNotFound)
+		return
+	}
+
+	timed, _ := strconv.ParseBool(r.URL.Query().Get("timed"))
+
+	from := time.Now().Add(-api.config.Calendar.History)
+	to := time.Now().AddDate(1, 0, 0)
+
+	entries, err := api.store.TimeEntries.List(r.Context(), time_entries.Filters{
+		UserId:   []string{strconv.FormatInt(userId, 10)},
+		FromDate: &from,
+		ToDate:   &to,
+	})
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	absenceList, err := api.store.Absences.List(r.Context(), userId, "", from, to)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	calendar := ical.Calendar{
+		Name:   "Tidsregistrering - " + user.Name,
+		Events: calendarEvents(entries, absenceList, timed, api.config.Calendar.DayStart),
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tidsregistrering.ics"`)
+
+	if err := ical.Write(w, calendar); err != nil {
+		api.logger.Error("failed to write calendar feed", "userId", userId, "error", err)
+	}
+}
+
+// calendarEvents turns time entries and absences into events. Timed events
+// of a day follow each other from dayStart, partial absences first, while
+// whole day absences are always all-day events.
+func calendarEvents(entries []time_entries.TimeEntry, absenceList []absences.Absence, timed bool, dayStart time.Duration) []ical.Event {
+	type item struct {
+		date     string
+		order    int64
+		duration time.Duration
+		event    ical.Event
+	}
+
+	items := make([]item, 0, len(entries)+len(absenceList))
+
+	for _, absence := range absenceList {
+		name := absenceKindNames[absence.Kind]
+		summary := name
+		if absence.Duration.Duration > 0 {
+			summary = fmt.Sprintf("%s (%s)", name, calendarHours(absence.Duration.Duration))
+		}
+
+		items = append(items, item{
+			date:     absence.Date,
+			order:    -absence.Id,
+			duration: absence.Duration.Duration,
+			event: ical.Event{
+				UID:         fmt.Sprintf("absence-%d@project-time-tracker", absence.Id),
+				Summary:     summary,
+				Description: absence.Description,
+				Categories:  []string{name},
+				AllDay:      !timed || absence.Duration.Duration == 0,
+			},
+		})
+	}
+
+	for _, entry := range entries {
+		items = append(items, item{
+			date:     entry.Date,
+			order:    entry.Id,
+			duration: entry.Duration.Duration,
+			event: ical.Event{
+				UID:         fmt.Sprintf("time-entry-%d@project-time-tracker", entry.Id),
+				Summary:     fmt.Sprintf("%s (%s)", entry.Category, calendarHours(entry.Duration.Duration)),
+				Description: entry.Description,
+				Categories:  []string{entry.Category},
+				AllDay:      !timed,
+			},
+		})
+	}
+
+	// absences have negative orders, so they come before the entries of a day
+	slices.SortFunc(items, func(a, b item) int {
+		return cmp.Or(strings.Compare(a.date, b.date), cmp.Compare(a.order, b.order))
+	})
+
+	events := make([]ical.Event, 0, len(items))
+
+	var (
+		day    string
+		cursor time.Time
+	)
+
+	for _, item := range items {
+		date, err := time.Parse(time.DateOnly, item.date)
+		if err != nil {
+			continue
+		}
+
+		if item.date != day {
+			day = item.date
+			cursor = date.Add(dayStart)
+		}
+
+		event := item.event
+		event.Start = date
+		if !event.AllDay {
+			event.Start = cursor
+			event.End = cursor.Add(item.duration)
+			cursor = event.End
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// calendarHours formats a duration as decimal hours, e.g. 1,5 t.
+func calendarHours(d time.Duration) string {
+	value := strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+	value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	return strings.Replace(value, ".", ",", 1) + " t"
+}
@@ -8,6 +8,10 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	Resend   ResendConfig
+	Holidays HolidaysConfig
+	Trash    TrashConfig
+	Budgets  BudgetsConfig
+	Calendar CalendarConfig
 }
 
 type ServerConfig struct {
@@ -17,6 +21,7 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `goenv:"SERVER_READ_TIMEOUT,default=10s"`
 	WriteTimeout time.Duration `goenv:"SERVER_WRITE_TIMEOUT,default=30s"`
 	IdleTimeout  time.Duration `goenv:"SERVER_IDLE_TIMEOUT,default=1m"`
+	PublicURL    string        `goenv:"SERVER_PUBLIC_URL,default=http://localhost:9090"` // base of links to the api, such as calendar feeds
 }
 
 type DatabaseConfig struct {
@@ -28,3 +33,22 @@ type ResendConfig struct {
 	From   string `goenv:"RESEND_FROM,default=Tidsregistrering <noreply@nemunivers.app>"` // format: "name <email>"
 	ApiKey string `goenv:"RESEND_API_KEY,required"`
 }
+
+type HolidaysConfig struct {
+	Country string `goenv:"HOLIDAYS_COUNTRY,default=DK"` // ISO 3166-1 alpha-2 code of a registered holiday rule set
+}
+
+type TrashConfig struct {
+	Retention time.Duration `goenv:"TRASH_RETENTION,default=720h"` // deleted time entries are purged after this duration
+}
+
+type BudgetsConfig struct {
+	CheckInterval time.Duration `goenv:"BUDGETS_CHECK_INTERVAL,default=15m"` // how often category budgets are checked for crossed alert thresholds
+}
+
+type CalendarConfig struct {
+	History   time.Duration `goenv:"CALENDAR_HISTORY,default=2160h"`              // how far back calendar feeds include time entries and absences
+	DayStart  time.Duration `goenv:"CALENDAR_DAY_START,default=8h"`               // time of day where timed calendar events start, e.g. 8h30m
+	TimeZone  string        `goenv:"CALENDAR_TIMEZONE,default=Europe/Copenhagen"` // zone of imported calendar events, and of events without a zone
+	ImportDir string        `goenv:"CALENDAR_IMPORT_DIR"`                         // directory with a folder of .ics files per user email, disabled when empty
+}
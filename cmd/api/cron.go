@@ -2,10 +2,7 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"slices"
 	"time"
 
@@ -14,8 +11,11 @@ import (
 	"github.com/go-co-op/gocron/v2"
 )
 
+// timezone is the zone of the cron jobs and of the current day.
+const timezone = "Europe/Berlin"
+
 func initCronScheduler() (gocron.Scheduler, error) {
-	tz, err := loadTimezone("Europe/Berlin")
+	tz, err := loadTimezone(timezone)
 
 	cronScheduler, err := gocron.NewScheduler(gocron.WithLocation(tz))
 	if err != nil {
@@ -29,6 +29,15 @@ func (api *api) createCronJobs() error {
 	if err := api.dailyJobAt(gocron.NewAtTime(06, 00, 00), api.notifyOnEmptyDay); err != nil {
 		return err
 	}
+	if err := api.dailyJobAt(gocron.NewAtTime(03, 00, 00), api.purgeTrash); err != nil {
+		return err
+	}
+	if err := api.intervalJob(api.config.Budgets.CheckInterval, api.alertOnBudgets); err != nil {
+		return err
+	}
+	if err := api.scheduleReportSubscriptions(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -52,6 +61,17 @@ func (api *api) dailyJobAt(at gocron.AtTime, fn func()) error {
 
 }
 
+func (api *api) intervalJob(interval time.Duration, fn func()) error {
+	if _, err := api.cron.NewJob(
+		gocron.DurationJob(interval),
+		gocron.NewTask(fn)); err != nil {
+		api.logger.Warn("failed to create cron job")
+		return err
+	}
+
+	return nil
+}
+
 func (api *api) notifyOnEmptyDay() {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -62,9 +82,9 @@ func (api *api) notifyOnEmptyDay() {
 		return
 	}
 
-	isHoliday, err := isDanishHoliday(yesterday)
+	isHoliday, err := api.isHoliday(ctx, yesterday)
 	if err != nil {
-		api.logger.Warn("[CRON JOB] notifyOnEmptyDay - failed to fetch day info", "error", err)
+		api.logger.Warn("[CRON JOB] notifyOnEmptyDay - failed to look up holidays", "error", err)
 		return
 	}
 
@@ -108,57 +128,93 @@ func (api *api) notifyOnEmptyDay() {
 	}
 }
 
-func isDanishHoliday(date time.Time) (bool, error) {
-	url := fmt.Sprintf("https://api.kalendarium.dk/Dayinfo/%s", date.Format("02-01-2006"))
+func (api *api) purgeTrash() {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
 
-	req, err := http.NewRequest("GET", url, nil)
+	purged, err := api.store.TimeEntries.Purge(ctx, time.Now().Add(-api.config.Trash.Retention))
 	if err != nil {
-		return false, err
+		api.logger.Warn("[CRON JOB] purgeTrash - failed to purge deleted time entries", "error", err)
+		return
 	}
 
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "application/json, text/plain, */*")
+	api.logger.Info("[CRON JOB] purgeTrash - purged deleted time entries", "count", purged)
+}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+// budgetThresholds are the percentages of a category budget that trigger an
+// alert when crossed, in increasing order.
+var budgetThresholds = []int{80, 100}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return false, err
-	}
-	defer res.Body.Close()
+func (api *api) alertOnBudgets() {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
 
-	body, err := io.ReadAll(res.Body)
+	budgets, err := api.store.Categories.Budgets(ctx)
 	if err != nil {
-		return false, err
+		api.logger.Warn("[CRON JOB] alertOnBudgets - failed to fetch budgets", "error", err)
+		return
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("api request to kalendarium failed with status %d: %s", res.StatusCode, string(body))
+	if len(budgets) == 0 {
+		return
 	}
 
-	type DayInfo struct {
-		Holiday bool `json:"holliday"`
-		Events  []struct {
-			Holiday bool `json:"holliday"`
-		} `json:"events"`
+	userList, err := api.store.Users.List(ctx)
+	if err != nil {
+		api.logger.Warn("[CRON JOB] alertOnBudgets - failed to fetch users", "error", err)
+		return
 	}
 
-	var dayInfo DayInfo
-	if err := json.Unmarshal(body, &dayInfo); err != nil {
-		return false, err
-	}
+	admins := slices.DeleteFunc(userList, func(u users.User) bool {
+		return !u.IsActive || u.Role != users.RoleAdmin
+	})
+
+	for _, budget := range budgets {
+		spent, err := api.store.TimeEntries.CategoryTotal(ctx, budget.Id)
+		if err != nil {
+			api.logger.Warn(fmt.Sprintf("[CRON JOB] alertOnBudgets - failed to get total for category %d", budget.Id), "error", err)
+			continue
+		}
+
+		percent := int(spent * 100 / budget.Budget.Duration)
 
-	isHoliday := dayInfo.Holiday
-	if !isHoliday {
-		for _, event := range dayInfo.Events {
-			if event.Holiday {
-				isHoliday = true
-				break
+		crossed := 0
+		for _, threshold := range budgetThresholds {
+			if percent >= threshold {
+				crossed = threshold
 			}
 		}
-	}
 
-	return isHoliday, nil
+		if crossed <= budget.Alerted {
+			continue
+		}
+
+		for _, admin := range admins {
+			mailData := struct {
+				User      users.User
+				Category  string
+				Percent   int
+				Budget    string
+				Spent     string
+				Remaining string
+			}{
+				User:      admin,
+				Category:  budget.Title,
+				Percent:   percent,
+				Budget:    budget.Budget.String(),
+				Spent:     spent.String(),
+				Remaining: (budget.Budget.Duration - spent).String(),
+			}
+
+			subject := fmt.Sprintf("%s har brugt %d%% af budgettet", budget.Title, crossed)
+
+			if err := api.mails.Send([]string{admin.Email}, subject, mailer.BudgetAlert, mailData); err != nil {
+				api.logger.Warn(fmt.Sprintf("[CRON JOB] alertOnBudgets - failed to send email to %s", admin.Email), "error", err)
+			}
+		}
+
+		if err := api.store.Categories.SetBudgetAlert(ctx, budget.Id, crossed); err != nil {
+			api.logger.Warn(fmt.Sprintf("[CRON JOB] alertOnBudgets - failed to record alert for category %d", budget.Id), "error", err)
+		}
+	}
 }
@@ -6,11 +6,18 @@ import (
 	"time"
 
 	"github.com/anvidev/apiduck"
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+	"github.com/anvidev/project-time-tracker/internal/store/calendar_feeds"
 	"github.com/anvidev/project-time-tracker/internal/store/categories"
 	"github.com/anvidev/project-time-tracker/internal/store/hours"
 	"github.com/anvidev/project-time-tracker/internal/store/sessions"
+	"github.com/anvidev/project-time-tracker/internal/store/suggestion_rules"
 	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/store/timesheets"
 	"github.com/anvidev/project-time-tracker/internal/store/users"
+	"github.com/anvidev/project-time-tracker/internal/store/vacation"
+	"github.com/anvidev/project-time-tracker/internal/suggestions"
 	"github.com/anvidev/project-time-tracker/internal/types"
 )
 
@@ -177,11 +184,12 @@ func initDocumentation(config Config) *apiduck.Documentation {
 			}),
 		)
 
-	meResource.Put("/v1/me/categories/{id}", "Opdater en kategori", "Opdater en kategoris titel").
+	meResource.Put("/v1/me/categories/{id}", "Opdater en kategori", "Opdater en kategoris titel, kode, beskrivelse, farve og sorteringsrækkefølge. Felter der udelades, bevares uændret").
 		Security("(bearer-token-for-users)").
 		PathParams(apiduck.PathParam("id", "Kategori id").Example(42)).
 		Body(apiduck.JSONBody(categories.UpdateCategoryInput{}).Example(categories.UpdateCategoryInput{
 			Title: "Ny Lagerløsning for kudne",
+			Color: ptr("#1f77b4"),
 		})).
 		Response(apiduck.JSONResponse(http.StatusCreated, struct {
 			Category categories.Category `json:"category"`
@@ -306,6 +314,50 @@ func initDocumentation(config Config) *apiduck.Documentation {
 			}),
 		)
 
+	meResource.Put("/v1/me/categories/{id}/follow/subtree", "Follow en kategori med underkategorier", "Follow en kategori og alle dens åbne underkategorier, som brugeren har adgang til").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Kategori id").Example(42),
+		).
+		Response(apiduck.JSONResponse(http.StatusOK, struct {
+			Followed int64 `json:"followed"`
+		}{}).Example(struct {
+			Followed int64 `json:"followed"`
+		}{
+			Followed: 4,
+		}).Description("Antal kategorier der nu er followed")).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "category not found",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Put("/v1/me/categories/{id}/unfollow/subtree", "Unfollow en kategori med underkategorier", "Unfollow en kategori og alle dens underkategorier").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Kategori id").Example(42),
+		).
+		Response(apiduck.JSONResponse(http.StatusOK, struct {
+			Unfollowed int64 `json:"unfollowed"`
+		}{}).Example(struct {
+			Unfollowed int64 `json:"unfollowed"`
+		}{
+			Unfollowed: 4,
+		}).Description("Antal kategorier der nu er unfollowed")).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
 	meResource.Post("/v1/me/time_entries", "Opret ny tidsregistrering", "Opret en ny tidsregistrering for en given dato").
 		Security("(bearer-token-for-users)").
 		Body(
@@ -383,7 +435,7 @@ func initDocumentation(config Config) *apiduck.Documentation {
 			}),
 		)
 
-	meResource.Delete("/v1/me/time_entries/{id}", "Slet en tidsregistrering", "Slet en tidsregistrering").
+	meResource.Delete("/v1/me/time_entries/{id}", "Slet en tidsregistrering", "Flyt en tidsregistrering til papirkurven").
 		Security("(bearer-token-for-users)").
 		PathParams(
 			apiduck.PathParam("id", "Tidsregistrerings id").Example(10),
@@ -404,6 +456,112 @@ func initDocumentation(config Config) *apiduck.Documentation {
 			}),
 		)
 
+	meResource.Get("/v1/me/time_entries/trash", "Hent papirkurv", "Hent slettede tidsregistreringer. Slettede tidsregistreringer fjernes permanent efter en periode").
+		Security("(bearer-token-for-users)").
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				TimeEntries []time_entries.TimeEntry `json:"timeEntries"`
+			}{}).Example(map[string]any{
+				"timeEntries": []time_entries.TimeEntry{
+					{
+						Id:          10,
+						CategoryId:  3,
+						Category:    "Support",
+						UserId:      23,
+						Date:        time.Now().Format(time.DateOnly),
+						Duration:    types.Duration{Duration: 1 * time.Hour},
+						Description: "Telefonsupport",
+						DeletedAt:   ptr(time.Now().Format(time.DateTime)),
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Get("/v1/me/time_entries/export", "Eksporter tidsregistreringer", "Eksporter egne tidsregistreringer som CSV eller XLSX. CSV bruger semikolon og decimalkomma, og varigheder angives i timer med decimaler").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("format", "Filformat, csv er standard").Enum("csv", "xlsx").Example("xlsx"),
+			apiduck.QueryParam("columns", "Kommasepareret liste af kolonner. Standard er date,user,category,hours,description,billable").Example("date,category,hours"),
+			apiduck.QueryParam("query", "Søg i beskrivelse og kategori").Example("møde"),
+			apiduck.QueryParam("categoryId", "Kommasepareret liste af kategori id'er").Example("1,2"),
+			apiduck.QueryParam("fromDate", "Fra og med dato (YYYY-MM-DD)").Example("2026-10-01"),
+			apiduck.QueryParam("toDate", "Til og med dato (YYYY-MM-DD)").Example("2026-10-31"),
+		).
+		Response(apiduck.JSONResponse(http.StatusOK, nil).Description("Filen sendes som vedhæftning")).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "unknown export column: week",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Get("/v1/me/time_entries/month/{year-month}/pdf", "Månedsrapport som PDF", "Hent en måneds registreringer som PDF til underskrift. Timesedlen viser hver dag med kategorier, beskrivelser og norm, og kategorirapporten grupperer registreringerne pr. kategori til kunder").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("report", "Rapporttype, timesheet er standard").Enum("timesheet", "categories").Example("categories"),
+			apiduck.QueryParam("categoryId", "Begræns kategorirapporten til en kategori og dens underkategorier").Example("4"),
+		).
+		Response(apiduck.JSONResponse(http.StatusOK, nil).Description("PDF-filen sendes som vedhæftning")).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "invalid month, use YYYY-MM",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "category not found",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Post("/v1/me/time_entries/{id}/restore", "Gendan en tidsregistrering", "Gendan en slettet tidsregistrering fra papirkurven").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Tidsregistrerings id").Example(10),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				TimeEntry time_entries.TimeEntry `json:"timeEntry"`
+			}{}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "time entry is not in the trash",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusLocked, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeLocked,
+				Error: "time entries in a locked period cannot be changed",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
 	meResource.Get("/v1/me/time_entries/day/{date}", "Hent tidsregistreringer for dato", "Hent tidsregistreringer for dato med samlet antal tid brugt").
 		Security("(bearer-token-for-users)").
 		PathParams(
@@ -594,5 +752,514 @@ func initDocumentation(config Config) *apiduck.Documentation {
 			}),
 		)
 
+	meResource.Get("/v1/me/absences", "Hent fravær", "Hent fravær i en periode, eventuelt filtreret på type").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("from", "Fra dato").Required().Example(time.Now().AddDate(0, -1, 0).Format(time.DateOnly)),
+			apiduck.QueryParam("to", "Til dato").Required().Example(time.Now().Format(time.DateOnly)),
+			apiduck.QueryParam("kind", "Type af fravær").Enum(
+				absences.KindVacation,
+				absences.KindSick,
+				absences.KindChildSick,
+				absences.KindLeave,
+				absences.KindOther,
+			),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Absences []absences.Absence `json:"absences"`
+			}{}).Example(map[string]any{
+				"absences": []absences.Absence{
+					{
+						Id:          4,
+						UserId:      12,
+						Date:        time.Now().Format(time.DateOnly),
+						Kind:        absences.KindVacation,
+						Description: "Sommerferie",
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "invalid from date",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Post("/v1/me/absences", "Registrer fravær", "Registrer fravær for en given dato. En varighed på 0s betyder hele dagen").
+		Security("(bearer-token-for-users)").
+		Body(
+			apiduck.JSONBody(absences.RegisterAbsenceInput{}).Example(absences.RegisterAbsenceInput{
+				Date:        time.Now().Format(time.DateOnly),
+				Kind:        absences.KindSick,
+				Duration:    types.Duration{Duration: 3 * time.Hour},
+				Description: "Tandlæge",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusCreated, struct {
+				Absence absences.Absence `json:"absence"`
+			}{}).Example(map[string]any{
+				"absence": absences.Absence{
+					Id:          5,
+					UserId:      12,
+					Date:        time.Now().Format(time.DateOnly),
+					Kind:        absences.KindSick,
+					Duration:    types.Duration{Duration: 3 * time.Hour},
+					Description: "Tandlæge",
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "invalid body",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusConflict, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeConflict,
+				Error: "the day already has an overlapping absence",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Delete("/v1/me/absences/{id}", "Slet fravær", "Slet en fraværsregistrering").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Fraværs id").Example(5),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNoContent, nil).Description("Fravær blev slettet"),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "absence not deleted",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Get("/v1/me/vacation", "Hent feriesaldo", "Hent optjente, afholdte og resterende feriedage for ferieåret der indeholder datoen").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("date", "Dato, standard er i dag").Example(time.Now().Format(time.DateOnly)),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Vacation vacation.Balance `json:"vacation"`
+			}{}).Example(map[string]any{
+				"vacation": vacation.Balance{
+					HolidayYear: 2025,
+					From:        "2025-09-01",
+					To:          "2026-08-31",
+					Allowance:   25,
+					Earned:      12.5,
+					CarriedOver: 3,
+					Used:        5,
+					Remaining:   10.5,
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Get("/v1/me/timesheets", "Hent timesedler", "Hent brugerens ugentlige og månedlige timesedler").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("status", "Status på timesedlen").Enum(
+				timesheets.StatusOpen,
+				timesheets.StatusSubmitted,
+				timesheets.StatusApproved,
+				timesheets.StatusRejected,
+			),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Timesheets []timesheets.Timesheet `json:"timesheets"`
+			}{}).Example(map[string]any{
+				"timesheets": []timesheets.Timesheet{
+					{
+						Id:          3,
+						UserId:      12,
+						UserName:    "John Doe",
+						Period:      timesheets.PeriodMonth,
+						StartDate:   "2026-09-01",
+						EndDate:     "2026-09-30",
+						Status:      timesheets.StatusRejected,
+						Comment:     "Mangler registreringer d. 14.",
+						SubmittedAt: ptr("2026-10-01 08:12:45"),
+						ReviewedBy:  ptr(int64(1)),
+						ReviewedAt:  ptr("2026-10-02 09:30:00"),
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Post("/v1/me/timesheets", "Indsend timeseddel", "Indsend ugen eller måneden der indeholder datoen til godkendelse. Tidsregistreringer i perioden kan ikke ændres mens timesedlen er indsendt eller godkendt").
+		Security("(bearer-token-for-users)").
+		Body(
+			apiduck.JSONBody(timesheets.SubmitTimesheetInput{}).Example(timesheets.SubmitTimesheetInput{
+				Period: timesheets.PeriodWeek,
+				Date:   time.Now().Format(time.DateOnly),
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusCreated, struct {
+				Timesheet timesheets.Timesheet `json:"timesheet"`
+			}{}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "invalid period",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusConflict, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeConflict,
+				Error: "timesheet is already submitted or approved",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Put("/v1/me/timesheets/{id}/withdraw", "Træk timeseddel tilbage", "Træk en indsendt timeseddel tilbage så tidsregistreringerne kan ændres igen").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Timeseddel id").Example(3),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Timesheet timesheets.Timesheet `json:"timesheet"`
+			}{}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusConflict, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeConflict,
+				Error: "timesheet cannot change to this status",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	calendarFeedExample := map[string]any{
+		"feed": calendar_feeds.Feed{
+			UserId:    1,
+			Token:     "q3G7x0kP2mZr8VtN4bLc6YhD1sWf9JeA5uKo2TiR",
+			CreatedAt: "2026-10-19 09:30:00",
+		},
+		"url":      "https://api.example.com/v1/ical/q3G7x0kP2mZr8VtN4bLc6YhD1sWf9JeA5uKo2TiR.ics",
+		"timedUrl": "https://api.example.com/v1/ical/q3G7x0kP2mZr8VtN4bLc6YhD1sWf9JeA5uKo2TiR.ics?timed=true",
+	}
+
+	meResource.Get("/v1/me/calendar", "Hent kalenderfeed", "Hent adressen på brugerens kalenderfeed, som kan abonneres på i en kalender").
+		Security("(bearer-token-for-users)").
+		Response(
+			apiduck.JSONResponse(http.StatusOK, calendarFeedExample).Example(calendarFeedExample),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "calendar feed not found",
+			}),
+		)
+
+	meResource.Post("/v1/me/calendar", "Opret kalenderfeed", "Opret brugerens kalenderfeed, eller giv det en ny adresse, så den gamle holder op med at virke").
+		Security("(bearer-token-for-users)").
+		Response(
+			apiduck.JSONResponse(http.StatusCreated, calendarFeedExample).Example(calendarFeedExample),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Delete("/v1/me/calendar", "Slet kalenderfeed", "Slet brugerens kalenderfeed, så adressen holder op med at virke").
+		Security("(bearer-token-for-users)").
+		Response(
+			apiduck.JSONResponse(http.StatusNoContent, nil).Description("Kalenderfeed blev slettet"),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "calendar feed not deleted",
+			}),
+		)
+
+	meResource.Post("/v1/me/time_entries/bulk", "Opret flere tidsregistreringer", "Opret op til 500 tidsregistreringer på én gang, f.eks. accepterede forslag. Fejler én registrering, oprettes ingen af dem").
+		Security("(bearer-token-for-users)").
+		Body(
+			apiduck.JSONBody(time_entries.RegisterTimeEntriesInput{}).Example(time_entries.RegisterTimeEntriesInput{
+				Entries: []time_entries.RegisterTimeEntryInput{
+					{
+						CategoryId:  42,
+						Date:        time.Now().Format(time.DateOnly),
+						Duration:    types.Duration{Duration: 15 * time.Minute},
+						Description: "Standup",
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusCreated, struct {
+				TimeEntries []time_entries.TimeEntry `json:"timeEntries"`
+			}{}).Example(map[string]any{
+				"timeEntries": []time_entries.TimeEntry{
+					{
+						Id:          3,
+						CategoryId:  42,
+						Category:    "Ny app idé",
+						UserId:      32,
+						Date:        time.Now().Format(time.DateOnly),
+						Duration:    types.Duration{Duration: 15 * time.Minute},
+						Description: "Standup",
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "entry 2: category not accessible",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
+	meResource.Post("/v1/me/time_entries/suggestions/calendar", "Foreslå tidsregistreringer fra kalender", "Send en .ics-fil som body, eller angiv en fil fra serverens kalendermappe, og få forslag til tidsregistreringer for begivenhederne i perioden. Kategorien vælges af brugerens kalenderregler ud fra begivenhedens titel. Heldagsbegivenheder springes over. Forslagene kan accepteres med /v1/me/time_entries/bulk").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("from", "Fra dato, som standard for 7 dage siden").Example(time.Now().AddDate(0, 0, -7).Format(time.DateOnly)),
+			apiduck.QueryParam("to", "Til og med dato, som standard i dag").Example(time.Now().Format(time.DateOnly)),
+			apiduck.QueryParam("file", "Navn på en fil i brugerens mappe i kalendermappen. Udelades, læses .ics-filen fra body").Example("arbejde.ics"),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Suggestions []suggestions.Suggestion `json:"suggestions"`
+			}{}).Example(map[string]any{
+				"suggestions": []suggestions.Suggestion{
+					{
+						Date:        time.Now().Format(time.DateOnly),
+						CategoryId:  ptr(int64(42)),
+						Category:    "Ny app idé",
+						RuleId:      ptr(int64(7)),
+						Duration:    types.Duration{Duration: 15 * time.Minute},
+						Description: "Standup",
+						Source:      "040000008200E00074C5B7101A82E008/20261019T091500",
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "the file is not an iCalendar file",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "calendar file not found",
+			}),
+		)
+
+	meResource.Post("/v1/me/time_entries/suggestions/git", "Foreslå tidsregistreringer fra git", "Send output fra git log som body, f.eks. fra git log --all --source, og få et forslag pr. dag og kategori for commits i perioden. Kategorien vælges af brugerens git-regler ud fra branch eller repository/branch, og varigheden anslås ud fra tiden mellem commits. Merge commits springes over").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("repository", "Navn på repository, som git-regler kan matche").Example("backend"),
+			apiduck.QueryParam("branch", "Branch for commits hvor loggen ikke angiver en").Example("main"),
+			apiduck.QueryParam("author", "Medtag kun commits hvor forfatterens navn eller email indeholder værdien").Example("anne@example.com"),
+			apiduck.QueryParam("from", "Fra dato, som standard for 7 dage siden").Example(time.Now().AddDate(0, 0, -7).Format(time.DateOnly)),
+			apiduck.QueryParam("to", "Til og med dato, som standard i dag").Example(time.Now().Format(time.DateOnly)),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Suggestions []suggestions.Suggestion `json:"suggestions"`
+			}{}).Example(map[string]any{
+				"suggestions": []suggestions.Suggestion{
+					{
+						Date:        time.Now().Format(time.DateOnly),
+						CategoryId:  ptr(int64(42)),
+						Category:    "Ny app idé",
+						RuleId:      ptr(int64(8)),
+						Duration:    types.Duration{Duration: 3*time.Hour + 15*time.Minute},
+						Description: "Tilføj login; Ret fejl i kalender",
+						Source:      "backend@c886f0f..d274025",
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "no commits found, use the default format of git log",
+			}),
+		)
+
+	suggestionRuleExample := suggestion_rules.Rule{
+		Id:         7,
+		UserId:     32,
+		Kind:       suggestion_rules.KindCalendar,
+		Pattern:    "standup",
+		CategoryId: 42,
+		Category:   "Ny app idé",
+		CreatedAt:  time.Now().Format(time.DateTime),
+	}
+
+	meResource.Get("/v1/me/suggestion_rules", "Hent forslagsregler", "Hent brugerens regler for hvilke kategorier forslag får. Kalenderregler matcher ord i begivenheders titler, og git-regler matcher starten af repository og branch").
+		Security("(bearer-token-for-users)").
+		Queries(
+			apiduck.QueryParam("kind", "Type af regel").Enum(suggestion_rules.KindCalendar, suggestion_rules.KindGit),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Rules []suggestion_rules.Rule `json:"rules"`
+			}{}).Example(map[string]any{
+				"rules": []suggestion_rules.Rule{suggestionRuleExample},
+			}),
+		)
+
+	meResource.Post("/v1/me/suggestion_rules", "Opret forslagsregel", "Opret en regel der giver forslag kategorien når mønsteret matcher. Længere mønstre vinder over kortere").
+		Security("(bearer-token-for-users)").
+		Body(
+			apiduck.JSONBody(suggestion_rules.CreateRuleInput{}).Example(suggestion_rules.CreateRuleInput{
+				Kind:       suggestion_rules.KindCalendar,
+				Pattern:    "standup",
+				CategoryId: 42,
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusCreated, struct {
+				Rule suggestion_rules.Rule `json:"rule"`
+			}{}).Example(map[string]any{
+				"rule": suggestionRuleExample,
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "category not found",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusConflict, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeConflict,
+				Error: "a rule with this pattern already exists",
+			}),
+		)
+
+	meResource.Delete("/v1/me/suggestion_rules/{id}", "Slet forslagsregel", "Slet en af brugerens forslagsregler").
+		Security("(bearer-token-for-users)").
+		PathParams(
+			apiduck.PathParam("id", "Regel id").Example(7),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNoContent, nil).Description("Reglen blev slettet"),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "suggestion rule not deleted",
+			}),
+		)
+
+	calendarResource := docs.AddResource("Calendar", "Kalenderfeeds")
+
+	calendarResource.Get("/v1/ical/{token}.ics", "Kalenderfeed", "Tidsregistreringer og fravær i iCalendar-format. Registreringer vises som heldagsbegivenheder, eller med timed=true efter hinanden fra dagens start").
+		PathParams(
+			apiduck.PathParam("token", "Hemmelig nøgle fra brugerens kalenderfeed").Example("q3G7x0kP2mZr8VtN4bLc6YhD1sWf9JeA5uKo2TiR"),
+		).
+		Queries(
+			apiduck.QueryParam("timed", "Vis registreringer med klokkeslæt i stedet for hele dage").Enum("true", "false").Example("true"),
+		).
+		Response(apiduck.JSONResponse(http.StatusOK, nil).Description("Kalender i text/calendar-format")).
+		Response(
+			apiduck.JSONResponse(http.StatusNotFound, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeNotFound,
+				Error: "calendar feed not found",
+			}),
+		)
+
+	holidaysResource := docs.AddResource("Holidays", "Helligdage og firmalukkedage")
+
+	holidaysResource.Get("/v1/holidays/{year}", "Hent helligdage for år", "Hent helligdage for det konfigurerede land samt firmalukkedage for et år").
+		PathParams(
+			apiduck.PathParam("year", "År").Example(time.Now().Year()),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusOK, struct {
+				Country  string             `json:"country"`
+				Holidays []holidays.Holiday `json:"holidays"`
+			}{}).Example(map[string]any{
+				"country": "DK",
+				"holidays": []holidays.Holiday{
+					{
+						Date: "2026-04-03",
+						Name: "Langfredag",
+						Kind: holidays.KindPublic,
+					},
+					{
+						Date: "2026-12-24",
+						Name: "Juleaftensdag",
+						Kind: holidays.KindClosing,
+					},
+				},
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusBadRequest, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeBadRequest,
+				Error: "invalid year",
+			}),
+		).
+		Response(
+			apiduck.JSONResponse(http.StatusInternalServerError, errorEnvelope{}).Example(errorEnvelope{
+				Code:  ErrorCodeInternal,
+				Error: "something went wrong",
+			}),
+		)
+
 	return docs
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/export"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+)
+
+var (
+	ErrUnknownExportColumn = errors.New("unknown export column")
+)
+
+type exportColumn struct {
+	header string
+	value  func(te time_entries.TimeEntry) export.Cell
+}
+
+var timeEntryExportColumns = map[string]exportColumn{
+	"id": {"Id", func(te time_entries.TimeEntry) export.Cell {
+		return export.Integer(te.Id)
+	}},
+	"date": {"Dato", func(te time_entries.TimeEntry) export.Cell {
+		return export.Text(te.Date)
+	}},
+	"userId": {"Medarbejder id", func(te time_entries.TimeEntry) export.Cell {
+		return export.Integer(te.UserId)
+	}},
+	"user": {"Medarbejder", func(te time_entries.TimeEntry) export.Cell {
+		return export.Text(te.UserName)
+	}},
+	"categoryId": {"Kategori id", func(te time_entries.TimeEntry) export.Cell {
+		return export.Integer(te.CategoryId)
+	}},
+	"category": {"Kategori", func(te time_entries.TimeEntry) export.Cell {
+		return export.Text(te.Category)
+	}},
+	"hours": {"Timer", func(te time_entries.TimeEntry) export.Cell {
+		return export.Decimal(math.Round(te.Duration.Hours()*100) / 100)
+	}},
+	"description": {"Beskrivelse", func(te time_entries.TimeEntry) export.Cell {
+		return export.Text(te.Description)
+	}},
+	"billable": {"Fakturerbar", func(te time_entries.TimeEntry) export.Cell {
+		if te.Billable {
+			return export.Text("Ja")
+		}
+		return export.Text("Nej")
+	}},
+}
+
+var defaultTimeEntryExportColumns = []string{"date", "user", "category", "hours", "description", "billable"}
+
+// parseExportColumns reads the comma separated columns query parameter,
+// falling back to the default columns.
+func parseExportColumns(r *http.Request) ([]exportColumn, error) {
+	names := defaultTimeEntryExportColumns
+	if value := r.URL.Query().Get("columns"); value != "" {
+		names = strings.Split(value, ",")
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		column, ok := timeEntryExportColumns[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownExportColumn, name)
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func (api *api) adminExportTimeEntries(w http.ResponseWriter, r *http.Request) {
+	var filters time_entries.Filters
+
+	if err := filters.Parse(r); err != nil {
+		switch err {
+		case
+			time_entries.ErrInvalidCategoryId,
+			time_entries.ErrInvalidUserId,
+			time_entries.ErrInvalidFromDate,
+			time_entries.ErrInvalidToDate,
+			time_entries.ErrFromDateAfterToDate,
+			time_entries.ErrToDateBeforeFromDate:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	api.exportTimeEntries(w, r, filters)
+}
+
+func (api *api) entriesExport(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var filters time_entries.Filters
+
+	if err := filters.Parse(r); err != nil {
+		switch err {
+		case
+			time_entries.ErrInvalidCategoryId,
+			time_entries.ErrInvalidFromDate,
+			time_entries.ErrInvalidToDate,
+			time_entries.ErrFromDateAfterToDate,
+			time_entries.ErrToDateBeforeFromDate:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	filters.UserId = []string{strconv.FormatInt(userId, 10)}
+
+	api.exportTimeEntries(w, r, filters)
+}
+
+// exportTimeEntries streams the time entries matching filters as a file in
+// the format given by the format query parameter.
+func (api *api) exportTimeEntries(w http.ResponseWriter, r *http.Request, filters time_entries.Filters) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		api.badRequestError(w, r, export.ErrUnknownFormat)
+		return
+	}
+
+	columns, err := parseExportColumns(r)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	// exports can take longer than the server write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(5 * time.Minute)); err != nil {
+		api.logger.Warn("failed to extend write deadline for export", "error", err)
+	}
+
+	filename := fmt.Sprintf("tidsregistreringer-%s.%s", time.Now().Format(time.DateOnly), format)
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	out, err := export.New(format, w)
+	if err != nil {
+		api.logger.Error("failed to write export", "error", err)
+		return
+	}
+
+	header := make([]export.Cell, len(columns))
+	for i, column := range columns {
+		header[i] = export.Text(column.header)
+	}
+
+	if err := out.Write(header); err != nil {
+		api.logger.Error("failed to write export", "error", err)
+		return
+	}
+
+	row := make([]export.Cell, len(columns))
+	err = api.store.TimeEntries.Each(r.Context(), filters, func(te time_entries.TimeEntry) error {
+		for i, column := range columns {
+			row[i] = column.value(te)
+		}
+		return out.Write(row)
+	})
+	if err != nil {
+		// the status code is already sent, so the file is left incomplete
+		api.logger.Error("failed to export time entries", "path", r.URL.Path, "error", err)
+		return
+	}
+
+	if err := out.Close(); err != nil {
+		api.logger.Error("failed to write export", "error", err)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -19,7 +22,9 @@ var (
 	ErrorCodeBadRequest             = "BAD_REQUEST"
 	ErrorCodeNotFound               = "NOT_FOUND"
 	ErrorCodeConflict               = "CONFLICT"
+	ErrorCodeLocked                 = "LOCKED"
 	ErrorCodeUnauthorized           = "UNAUTHORIZED"
+	ErrorCodeForbidden              = "FORBIDDEN"
 	ErrorCodeTooManyRequests        = "TOO_MANY_REQUESTS"
 	ErrorCodeRequestTimeout         = "REQUEST_TIMEOUT"
 )
@@ -54,6 +59,38 @@ func (api *api) writeJSON(w http.ResponseWriter, status int, v any) error {
 	return json.NewEncoder(w).Encode(v)
 }
 
+// query helpers
+func parseOptionalDate(r *http.Request, key string, fallback time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return date, nil
+}
+
+// parseIds reads a comma separated list of ids, ignoring empty items.
+func parseIds(value string) ([]int64, error) {
+	ids := []int64{}
+	for item := range strings.SplitSeq(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // error response helpers
 func (api *api) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	api.logger.Error("internal server error",
@@ -91,6 +128,15 @@ func (api *api) conflictError(w http.ResponseWriter, r *http.Request, err error)
 	api.writeJSON(w, http.StatusConflict, newErrorEnvelope(err.Error(), ErrorCodeConflict))
 }
 
+func (api *api) lockedError(w http.ResponseWriter, r *http.Request, err error) {
+	api.logger.Warn("locked error",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err.Error())
+
+	api.writeJSON(w, http.StatusLocked, newErrorEnvelope(err.Error(), ErrorCodeLocked))
+}
+
 func (api *api) unauthorizedError(w http.ResponseWriter, r *http.Request, err error) {
 	api.logger.Warn("unauthorized error",
 		"method", r.Method,
@@ -100,6 +146,15 @@ func (api *api) unauthorizedError(w http.ResponseWriter, r *http.Request, err er
 	api.writeJSON(w, http.StatusUnauthorized, newErrorEnvelope(err.Error(), ErrorCodeUnauthorized))
 }
 
+func (api *api) forbiddenError(w http.ResponseWriter, r *http.Request, err error) {
+	api.logger.Warn("forbidden error",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err.Error())
+
+	api.writeJSON(w, http.StatusForbidden, newErrorEnvelope(err.Error(), ErrorCodeForbidden))
+}
+
 func (api *api) tooManyRequestsError(w http.ResponseWriter, r *http.Request, err error) {
 	api.logger.Warn("too many requests error",
 		"method", r.Method,
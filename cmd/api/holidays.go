@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+)
+
+func (api *api) holidaysYear(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	holidayList, err := api.holidaysForYear(r.Context(), year)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"country":  api.holidays.Country(),
+		"holidays": holidayList,
+	}
+
+	w.Header().Add("Cache-Control", "public, max-age=3600")
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// holidaysForYear merges the public holidays of the configured country with
+// the company closing days of the year.
+func (api *api) holidaysForYear(ctx context.Context, year int) ([]holidays.Holiday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	closingDays, err := api.store.ClosingDays.List(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayList := api.holidays.Holidays(year)
+	for _, day := range closingDays {
+		holidayList = append(holidayList, day.Holiday())
+	}
+	holidays.Sort(holidayList)
+
+	return holidayList, nil
+}
+
+// isHoliday reports whether date is a public holiday or a day the company is
+// closed. Closing days with reduced hours are not holidays.
+func (api *api) isHoliday(ctx context.Context, date time.Time) (bool, error) {
+	if _, ok := holidays.Find(api.holidays, date); ok {
+		return true, nil
+	}
+
+	closingDays, err := api.store.ClosingDays.List(ctx, date, date)
+	if err != nil {
+		return false, err
+	}
+
+	for _, day := range closingDays {
+		if day.Holiday().IsDayOff() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
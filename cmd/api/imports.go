@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/anvidev/project-time-tracker/internal/imports"
+	"github.com/anvidev/project-time-tracker/internal/store/import_mappings"
+)
+
+const (
+	maxImportSize int64 = 20_971_520 // 20mb
+)
+
+// adminImportTimeEntries imports time entries for any user from a file sent
+// as the request body, in the format given by ?format. With ?dryRun=true the
+// rows are only validated. A committed import with errors imports nothing
+// and responds with 422 and the errors.
+func (api *api) adminImportTimeEntries(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			api.badRequestError(w, r, errors.New("invalid dryRun, use true or false"))
+			return
+		}
+	}
+
+	source, rows, ok := api.readImport(w, r)
+	if !ok {
+		return
+	}
+
+	directory, err := api.importDirectory(r, source)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	result, err := imports.Run(r.Context(), api.store.TimeEntries, directory, rows, dryRun)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !dryRun && len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	response := map[string]any{
+		"import": result,
+	}
+
+	if err := api.writeJSON(w, status, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// adminProposeImportMappings reads a file from another tracker and lists its
+// projects and users with their confirmed or suggested mappings.
+func (api *api) adminProposeImportMappings(w http.ResponseWriter, r *http.Request) {
+	source, rows, ok := api.readImport(w, r)
+	if !ok {
+		return
+	}
+
+	if !imports.IsExternal(source) {
+		api.badRequestError(w, r, errors.New("mappings are only used for toggl, clockify and harvest"))
+		return
+	}
+
+	directory, err := api.importDirectory(r, source)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"mappings": imports.Propose(rows, directory),
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// readImport parses the request body with the parser of ?format, which
+// defaults to csv. It writes the error response and returns false when the
+// file cannot be read.
+func (api *api) readImport(w http.ResponseWriter, r *http.Request) (string, []imports.Row, bool) {
+	source := r.URL.Query().Get("format")
+	if source == "" {
+		source = imports.SourceCSV
+	}
+
+	parser, err := imports.ParserFor(source)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return "", nil, false
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	rows, err := parser.Parse(r.Body)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return "", nil, false
+	}
+
+	return source, rows, true
+}
+
+func (api *api) importDirectory(r *http.Request, source string) (*imports.Directory, error) {
+	users, err := api.store.Users.List(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := api.store.Categories.Paths(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	directory := imports.NewDirectory(users, paths)
+
+	if imports.IsExternal(source) {
+		mappings, err := api.store.ImportMappings.List(r.Context(), source)
+		if err != nil {
+			return nil, err
+		}
+		directory.UseMappings(mappings)
+	}
+
+	return directory, nil
+}
+
+func (api *api) adminImportMappings(w http.ResponseWriter, r *http.Request) {
+	source := r.PathValue("source")
+	if !imports.IsExternal(source) {
+		api.badRequestError(w, r, imports.ErrUnknownSource)
+		return
+	}
+
+	mappings, err := api.store.ImportMappings.List(r.Context(), source)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"mappings": mappings,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// adminSaveImportMappings confirms mappings of a source, typically the
+// proposals of adminProposeImportMappings after the admin has corrected them.
+func (api *api) adminSaveImportMappings(w http.ResponseWriter, r *http.Request) {
+	source := r.PathValue("source")
+	if !imports.IsExternal(source) {
+		api.badRequestError(w, r, imports.ErrUnknownSource)
+		return
+	}
+
+	var input import_mappings.SaveMappingsInput
+	if err := api.readJSON(w, r, &input); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	for i := range input.Mappings {
+		input.Mappings[i].ExternalKey = imports.NormalizeKey(input.Mappings[i].ExternalKey)
+	}
+
+	if err := api.store.ImportMappings.Save(r.Context(), source, input.Mappings); err != nil {
+		switch err {
+		case import_mappings.ErrUnknownCategoryId, import_mappings.ErrUnknownUserId:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	mappings, err := api.store.ImportMappings.List(r.Context(), source)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"mappings": mappings,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminDeleteImportMapping(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.ImportMappings.Delete(r.Context(), r.PathValue("source"), id); err != nil {
+		switch err {
+		case import_mappings.ErrMappingNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
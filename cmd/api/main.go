@@ -3,6 +3,9 @@ package main
 import (
 	"context"
 	"log"
+
+	// the production image has no zoneinfo, calendar imports need it
+	_ "time/tzdata"
 )
 
 func main() {
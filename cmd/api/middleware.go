@@ -8,6 +8,7 @@ import (
 
 	"github.com/anvidev/project-time-tracker/internal/contextkeys"
 	"github.com/anvidev/project-time-tracker/internal/store/sessions"
+	"github.com/anvidev/project-time-tracker/internal/store/users"
 )
 
 func (api *api) bearerAuthorization(next http.Handler) http.Handler {
@@ -45,6 +46,31 @@ func (api *api) bearerAuthorization(next http.Handler) http.Handler {
 	})
 }
 
+func (api *api) adminAuthorization(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userId, _ := getUserId(ctx)
+
+		user, err := api.store.Users.GetById(ctx, userId)
+		if err != nil {
+			switch err {
+			case users.ErrUserNotFound:
+				api.unauthorizedError(w, r, fmt.Errorf("access denied"))
+			default:
+				api.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		if user.Role != users.RoleAdmin {
+			api.forbiddenError(w, r, fmt.Errorf("admin access required"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func getUserId(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(contextkeys.UserId).(int64)
 	return userID, ok
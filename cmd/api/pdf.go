@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/pdf"
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/users"
+)
+
+const (
+	pdfReportTimesheet  string = "timesheet"
+	pdfReportCategories        = "categories"
+)
+
+var (
+	ErrInvalidYearMonth = errors.New("invalid month, use YYYY-MM")
+	ErrUnknownPDFReport = errors.New("unknown report, use timesheet or categories")
+)
+
+func (api *api) entriesMonthPDF(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	api.monthPDF(w, r, userId)
+}
+
+func (api *api) adminUserMonthPDF(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	api.monthPDF(w, r, userId)
+}
+
+// monthPDF renders the month of a user as a timesheet, or with
+// ?report=categories as a report grouped by category, optionally limited to
+// the subtree of ?categoryId.
+func (api *api) monthPDF(w http.ResponseWriter, r *http.Request, userId int64) {
+	month, err := time.Parse("2006-01", r.PathValue("year-month"))
+	if err != nil {
+		api.badRequestError(w, r, ErrInvalidYearMonth)
+		return
+	}
+
+	report := r.URL.Query().Get("report")
+	if report == "" {
+		report = pdfReportTimesheet
+	}
+
+	if report != pdfReportTimesheet && report != pdfReportCategories {
+		api.badRequestError(w, r, ErrUnknownPDFReport)
+		return
+	}
+
+	user, err := api.store.Users.GetById(r.Context(), userId)
+	if err != nil {
+		switch err {
+		case users.ErrUserNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	summary, err := api.store.TimeEntries.SummaryMonth(r.Context(), userId, month.Month(), month.Year())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	// rendered to a buffer first, so errors can still be sent as json
+	var buf bytes.Buffer
+
+	switch report {
+	case pdfReportTimesheet:
+		err = pdf.Timesheet(&buf, user.Name, month.Year(), month.Month(), summary)
+	case pdfReportCategories:
+		scope, paths, ok := api.pdfCategoryPaths(w, r)
+		if !ok {
+			return
+		}
+		err = pdf.CategoryReport(&buf, user.Name, month.Year(), month.Month(), scope, paths, summary)
+	}
+
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s-%s.pdf", report, strings.ReplaceAll(strings.ToLower(user.Name), " ", "-"), month.Format("2006-01"))
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := buf.WriteTo(w); err != nil {
+		api.logger.Error("failed to write pdf", "path", r.URL.Path, "error", err)
+	}
+}
+
+// pdfCategoryPaths returns the paths of the categories a category report
+// covers, all categories or the subtree of ?categoryId, and a description
+// of them. It writes the error response and returns false on errors.
+func (api *api) pdfCategoryPaths(w http.ResponseWriter, r *http.Request) (string, map[int64]string, bool) {
+	all, err := api.store.Categories.Paths(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return "", nil, false
+	}
+
+	paths := make(map[int64]string, len(all))
+	for _, path := range all {
+		paths[path.Id] = path.Path
+	}
+
+	value := r.URL.Query().Get("categoryId")
+	if value == "" {
+		return "Alle", paths, true
+	}
+
+	categoryId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return "", nil, false
+	}
+
+	ids, err := api.store.Categories.Subtree(r.Context(), categoryId)
+	if err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return "", nil, false
+	}
+
+	subtree := make(map[int64]string, len(ids))
+	for _, id := range ids {
+		subtree[id] = paths[id]
+	}
+
+	return paths[categoryId], subtree, true
+}
@@ -0,0 +1,571 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/export"
+	"github.com/anvidev/project-time-tracker/internal/mailer"
+	"github.com/anvidev/project-time-tracker/internal/store/report_subscriptions"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/utilization"
+	"github.com/go-co-op/gocron/v2"
+	"github.com/robfig/cron/v3"
+)
+
+// defaultSummaryGroupBy is used by summary subscriptions without groupBy in
+// their filters.
+const defaultSummaryGroupBy = "user"
+
+var (
+	ErrInvalidSchedule = errors.New("schedule must be a cron expression with five fields, e.g. 0 7 * * 1")
+)
+
+// groupByHeaders are the column headers of the summary report dimensions.
+var groupByHeaders = map[string]string{
+	time_entries.GroupByUser:         "Medarbejder",
+	time_entries.GroupByCategory:     "Kategori",
+	time_entries.GroupByRootCategory: "Hovedkategori",
+	time_entries.GroupByWeek:         "Uge",
+	time_entries.GroupByMonth:        "Måned",
+}
+
+func (api *api) adminReportSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := api.store.ReportSubscriptions.List(r.Context())
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"subscriptions": subscriptions,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminCreateReportSubscription(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body report_subscriptions.SubscriptionInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	job, err := checkSubscription(body)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	sub, err := api.store.ReportSubscriptions.Create(r.Context(), userId, body)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	if err := api.scheduleReportSubscription(sub.Id, job); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"subscription": sub,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminUpdateReportSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var body report_subscriptions.SubscriptionInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	job, err := checkSubscription(body)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	sub, err := api.store.ReportSubscriptions.Update(r.Context(), id, body)
+	if err != nil {
+		switch err {
+		case report_subscriptions.ErrSubscriptionNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := api.scheduleReportSubscription(sub.Id, job); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"subscription": sub,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) adminDeleteReportSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.ReportSubscriptions.Delete(r.Context(), id); err != nil {
+		switch err {
+		case report_subscriptions.ErrSubscriptionNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if api.cronInitialized {
+		api.cron.RemoveByTags(subscriptionTag(id))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// adminSendReportSubscription mails the report of a subscription right away,
+// e.g. to check how it looks.
+func (api *api) adminSendReportSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	sub, err := api.store.ReportSubscriptions.Get(r.Context(), id)
+	if err != nil {
+		switch err {
+		case report_subscriptions.ErrSubscriptionNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := api.sendReport(r.Context(), *sub, time.Now()); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkSubscription parses the schedule and filters of a subscription the
+// way they are parsed when the report is sent, and returns the job
+// definition of the schedule. It is called before the subscription is
+// stored, so a schedule the scheduler refuses never reaches the database.
+func checkSubscription(input report_subscriptions.SubscriptionInput) (gocron.JobDefinition, error) {
+	job, err := subscriptionJob(input.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := url.ParseQuery(input.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filters: %w", err)
+	}
+
+	switch input.Report {
+	case report_subscriptions.ReportSummary:
+		_, _, err = summaryFilters(values)
+	case report_subscriptions.ReportUtilization:
+		_, _, err = utilizationFilters(values)
+	}
+
+	return job, err
+}
+
+// subscriptionJob builds the job definition of a schedule. The expression is
+// parsed like gocron parses it, and descriptors such as @hourly or @every 1s
+// are rejected, so reports are not mailed more often than a five-field cron
+// expression allows.
+func subscriptionJob(schedule string) (gocron.JobDefinition, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 || strings.HasPrefix(fields[0], "@") {
+		return nil, ErrInvalidSchedule
+	}
+
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return nil, ErrInvalidSchedule
+	}
+
+	return gocron.CronJob(schedule, false), nil
+}
+
+func summaryFilters(values url.Values) (time_entries.Filters, []string, error) {
+	var filters time_entries.Filters
+	if err := filters.ParseValues(values); err != nil {
+		return filters, nil, err
+	}
+
+	groupBy := values.Get("groupBy")
+	if groupBy == "" {
+		groupBy = defaultSummaryGroupBy
+	}
+
+	dimensions, err := time_entries.ParseGroupBy(groupBy)
+	return filters, dimensions, err
+}
+
+func utilizationFilters(values url.Values) ([]int64, string, error) {
+	period, err := utilization.ParsePeriod(values.Get("period"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	userIds, err := parseIds(values.Get("userId"))
+	if err != nil {
+		return nil, "", time_entries.ErrInvalidUserId
+	}
+
+	return userIds, period, nil
+}
+
+func subscriptionTag(id int64) string {
+	return fmt.Sprintf("report-subscription-%d", id)
+}
+
+// scheduleReportSubscriptions schedules every stored subscription. A
+// subscription that cannot be scheduled is logged and skipped, so it does
+// not keep the server from starting.
+func (api *api) scheduleReportSubscriptions() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	subscriptions, err := api.store.ReportSubscriptions.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subscriptions {
+		job, err := subscriptionJob(sub.Schedule)
+		if err != nil {
+			api.logger.Warn("failed to schedule report subscription", "id", sub.Id, "error", err)
+			continue
+		}
+
+		if err := api.scheduleReportSubscription(sub.Id, job); err != nil {
+			api.logger.Warn("failed to schedule report subscription", "id", sub.Id, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// scheduleReportSubscription replaces the job of a subscription with one
+// running on the given job definition. Without a scheduler nothing is
+// scheduled.
+func (api *api) scheduleReportSubscription(id int64, job gocron.JobDefinition) error {
+	if !api.cronInitialized {
+		return nil
+	}
+
+	tag := subscriptionTag(id)
+
+	api.cron.RemoveByTags(tag)
+
+	_, err := api.cron.NewJob(
+		job,
+		gocron.NewTask(api.sendReportSubscription, id),
+		gocron.WithTags(tag),
+	)
+
+	return err
+}
+
+func (api *api) sendReportSubscription(id int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	sub, err := api.store.ReportSubscriptions.Get(ctx, id)
+	if err != nil {
+		api.logger.Warn(fmt.Sprintf("[CRON JOB] sendReportSubscription - failed to fetch subscription %d", id), "error", err)
+		return
+	}
+
+	if err := api.sendReport(ctx, *sub, time.Now()); err != nil {
+		api.logger.Warn(fmt.Sprintf("[CRON JOB] sendReportSubscription - failed to send report %d to %s", id, sub.Recipient), "error", err)
+	}
+}
+
+// sendReport mails the report of a subscription for the span before now.
+func (api *api) sendReport(ctx context.Context, sub report_subscriptions.Subscription, now time.Time) error {
+	from, to := reportSpan(sub.Span, now)
+
+	values, err := url.ParseQuery(sub.Filters)
+	if err != nil {
+		return err
+	}
+
+	var (
+		subject  string
+		tmpl     string
+		data     any
+		filename = fmt.Sprintf("%s-%s-%s.csv", sub.Report, from.Format(time.DateOnly), to.Format(time.DateOnly))
+		csv      = new(bytes.Buffer)
+	)
+
+	switch sub.Report {
+	case report_subscriptions.ReportSummary:
+		filters, groupBy, err := summaryFilters(values)
+		if err != nil {
+			return err
+		}
+		filters.FromDate = &from
+		filters.ToDate = &to
+
+		groups, err := api.store.TimeEntries.Totals(ctx, filters, groupBy)
+		if err != nil {
+			return err
+		}
+
+		subject = fmt.Sprintf("Tidsoversigt %s – %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+		tmpl = mailer.ReportSummary
+		data = summaryMailData(from, to, groupBy, groups)
+		err = writeSummary(csv, groupBy, groups)
+		if err != nil {
+			return err
+		}
+
+	case report_subscriptions.ReportUtilization:
+		userIds, period, err := utilizationFilters(values)
+		if err != nil {
+			return err
+		}
+
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		reports, err := api.utilizationReports(ctx, userIds, from, to, today, period)
+		if err != nil {
+			return err
+		}
+
+		subject = fmt.Sprintf("Udnyttelse %s – %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+		tmpl = mailer.ReportUtilization
+		data = utilizationMailData(from, to, reports)
+		err = writeUtilization(csv, export.FormatCSV, reports)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown report %q", sub.Report)
+	}
+
+	var attachments []mailer.Attachment
+	if sub.AttachCSV {
+		attachments = append(attachments, mailer.Attachment{
+			Filename:    filename,
+			ContentType: export.ContentType(export.FormatCSV),
+			Content:     csv.Bytes(),
+		})
+	}
+
+	if err := api.mails.Send([]string{sub.Recipient}, subject, tmpl, data, attachments...); err != nil {
+		return err
+	}
+
+	return api.store.ReportSubscriptions.MarkSent(ctx, sub.Id, now)
+}
+
+// reportSpan returns the first and last date of the day, monday to sunday
+// week or month before now.
+func reportSpan(span string, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch span {
+	case report_subscriptions.SpanWeek:
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7-7)
+		return monday, monday.AddDate(0, 0, 6)
+	case report_subscriptions.SpanMonth:
+		first := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+		return first, first.AddDate(0, 1, -1)
+	default:
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday
+	}
+}
+
+// groupLabels returns the value of each groupBy dimension of a group.
+func groupLabels(groupBy []string, group time_entries.GroupTotals) []string {
+	labels := make([]string, len(groupBy))
+	for i, dimension := range groupBy {
+		switch dimension {
+		case time_entries.GroupByUser:
+			labels[i] = group.User
+		case time_entries.GroupByCategory:
+			labels[i] = group.Category
+		case time_entries.GroupByRootCategory:
+			labels[i] = group.RootCategory
+		case time_entries.GroupByWeek:
+			labels[i] = group.Week
+		case time_entries.GroupByMonth:
+			labels[i] = group.Month
+		}
+	}
+	return labels
+}
+
+func summaryMailData(from, to time.Time, groupBy []string, groups []time_entries.GroupTotals) any {
+	var (
+		timeSpent    time.Duration
+		billableTime time.Duration
+		entries      int
+		headers      []string
+		rows         [][]string
+	)
+
+	for _, dimension := range groupBy {
+		headers = append(headers, groupByHeaders[dimension])
+	}
+	headers = append(headers, "Timer", "Fakturerbare timer", "Registreringer")
+
+	for _, group := range groups {
+		timeSpent += group.TimeSpent.Duration
+		billableTime += group.BillableTime.Duration
+		entries += group.Entries
+
+		row := append(groupLabels(groupBy, group),
+			danishHours(group.TimeSpent.Duration),
+			danishHours(group.BillableTime.Duration),
+			strconv.Itoa(group.Entries),
+		)
+		rows = append(rows, row)
+	}
+
+	return struct {
+		From         string
+		To           string
+		TimeSpent    string
+		BillableTime string
+		Entries      int
+		Headers      []string
+		Rows         [][]string
+	}{
+		From:         from.Format(time.DateOnly),
+		To:           to.Format(time.DateOnly),
+		TimeSpent:    danishHours(timeSpent),
+		BillableTime: danishHours(billableTime),
+		Entries:      entries,
+		Headers:      headers,
+		Rows:         rows,
+	}
+}
+
+func utilizationMailData(from, to time.Time, reports []utilization.Report) any {
+	type row struct {
+		UserName      string
+		From          string
+		To            string
+		Expected      string
+		Registered    string
+		Utilization   string
+		BillableShare string
+		MissingDays   string
+	}
+
+	rows := make([]row, len(reports))
+	for i, report := range reports {
+		rows[i] = row{
+			UserName:      report.UserName,
+			From:          report.From,
+			To:            report.To,
+			Expected:      danishHours(report.ExpectedHours.Duration),
+			Registered:    danishHours(report.RegisteredHours.Duration),
+			Utilization:   fmt.Sprintf("%.0f%%", report.Utilization*100),
+			BillableShare: fmt.Sprintf("%.0f%%", report.BillableShare*100),
+			MissingDays:   strings.Join(report.MissingDays, ", "),
+		}
+	}
+
+	return struct {
+		From string
+		To   string
+		Rows []row
+	}{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Rows: rows,
+	}
+}
+
+// writeSummary writes the groups of a summary report as CSV.
+func writeSummary(w io.Writer, groupBy []string, groups []time_entries.GroupTotals) error {
+	out, err := export.NewCSV(w)
+	if err != nil {
+		return err
+	}
+
+	var header []export.Cell
+	for _, dimension := range groupBy {
+		header = append(header, export.Text(groupByHeaders[dimension]))
+	}
+	header = append(header, export.Text("Timer"), export.Text("Fakturerbare timer"), export.Text("Registreringer"))
+
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		var row []export.Cell
+		for _, label := range groupLabels(groupBy, group) {
+			row = append(row, export.Text(label))
+		}
+		row = append(row,
+			export.Decimal(group.TimeSpent.Hours()),
+			export.Decimal(group.BillableTime.Hours()),
+			export.Integer(int64(group.Entries)),
+		)
+
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return out.Close()
+}
+
+// danishHours formats a duration as hours with a decimal comma, e.g. 7,50.
+func danishHours(d time.Duration) string {
+	return strings.Replace(strconv.FormatFloat(d.Hours(), 'f', 2, 64), ".", ",", 1)
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/export"
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/store/users"
+	"github.com/anvidev/project-time-tracker/internal/utilization"
+)
+
+// adminReportsSummary sums time entries matching the filters of
+// adminTimeEntries per group of ?groupBy, so charts over long periods don't
+// need every entry.
+func (api *api) adminReportsSummary(w http.ResponseWriter, r *http.Request) {
+	var filters time_entries.Filters
+
+	if err := filters.Parse(r); err != nil {
+		switch err {
+		case
+			time_entries.ErrInvalidCategoryId,
+			time_entries.ErrInvalidUserId,
+			time_entries.ErrInvalidFromDate,
+			time_entries.ErrInvalidToDate,
+			time_entries.ErrFromDateAfterToDate,
+			time_entries.ErrToDateBeforeFromDate:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	groupBy, err := time_entries.ParseGroupBy(r.URL.Query().Get("groupBy"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	groups, err := api.store.TimeEntries.Totals(r.Context(), filters, groupBy)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	var (
+		timeSpent    time.Duration
+		billableTime time.Duration
+		entries      int
+	)
+
+	for _, group := range groups {
+		timeSpent += group.TimeSpent.Duration
+		billableTime += group.BillableTime.Duration
+		entries += group.Entries
+	}
+
+	response := map[string]any{
+		"groupBy":      groupBy,
+		"timeSpent":    timeSpent.String(),
+		"billableTime": billableTime.String(),
+		"entries":      entries,
+		"groups":       groups,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+const maxReportRange = 366 * 24 * time.Hour
+
+var (
+	ErrReportRange         = errors.New("from must be before to and at most a year apart")
+	ErrUnknownReportFormat = errors.New("unknown report format, must be json, csv or xlsx")
+)
+
+// adminReportsUtilization compares the expected and registered hours of
+// users, by default the active users in the previous week, as JSON or with
+// ?format as a CSV or XLSX file.
+func (api *api) adminReportsUtilization(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	lastMonday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7-7)
+
+	from, err := parseOptionalDate(r, "from", lastMonday)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	to, err := parseOptionalDate(r, "to", lastMonday.AddDate(0, 0, 6))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if to.Before(from) || to.Sub(from) > maxReportRange {
+		api.badRequestError(w, r, ErrReportRange)
+		return
+	}
+
+	period, err := utilization.ParsePeriod(query.Get("period"))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	userIds, err := parseIds(query.Get("userId"))
+	if err != nil {
+		api.badRequestError(w, r, time_entries.ErrInvalidUserId)
+		return
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != export.FormatCSV && format != export.FormatXLSX {
+		api.badRequestError(w, r, ErrUnknownReportFormat)
+		return
+	}
+
+	reports, err := api.utilizationReports(r.Context(), userIds, from, to, today, period)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	if format == export.FormatCSV || format == export.FormatXLSX {
+		filename := fmt.Sprintf("udnyttelse-%s-%s.%s", from.Format(time.DateOnly), to.Format(time.DateOnly), format)
+
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		if err := writeUtilization(w, format, reports); err != nil {
+			api.logger.Error("failed to write utilization report", "error", err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"from":    from.Format(time.DateOnly),
+		"to":      to.Format(time.DateOnly),
+		"period":  period,
+		"reports": reports,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// utilizationReports reports on the active users among userIds, or on all
+// active users when userIds is empty.
+func (api *api) utilizationReports(ctx context.Context, userIds []int64, from, to, today time.Time, period string) ([]utilization.Report, error) {
+	userList, err := api.store.Users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userList = slices.DeleteFunc(userList, func(u users.User) bool {
+		return !u.IsActive || (len(userIds) > 0 && !slices.Contains(userIds, u.Id))
+	})
+
+	var holidayList []holidays.Holiday
+	for year := from.Year(); year <= to.Year(); year++ {
+		yearHolidays, err := api.holidaysForYear(ctx, year)
+		if err != nil {
+			return nil, err
+		}
+		holidayList = append(holidayList, yearHolidays...)
+	}
+
+	ids := make([]int64, len(userList))
+	for i, user := range userList {
+		ids[i] = user.Id
+	}
+
+	dayTotals, err := api.store.TimeEntries.DayTotals(ctx, ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []utilization.Report{}
+
+	for _, user := range userList {
+		weekdays, err := api.store.Hours.AllWeekdays(ctx, user.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		absenceList, err := api.store.Absences.List(ctx, user.Id, "", from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		days := slices.DeleteFunc(slices.Clone(dayTotals), func(d time_entries.DayTotals) bool {
+			return d.UserId != user.Id
+		})
+
+		userReports, err := utilization.Calculate(utilization.User{
+			Id:       user.Id,
+			Name:     user.Name,
+			Weekdays: weekdays,
+			Absences: absenceList,
+			Days:     days,
+		}, holidayList, from, to, today, period)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, userReports...)
+	}
+
+	utilization.Sort(reports)
+
+	return reports, nil
+}
+
+// writeUtilization writes reports as a CSV or XLSX file with hours and
+// shares as numbers.
+func writeUtilization(w io.Writer, format string, reports []utilization.Report) error {
+	out, err := export.New(format, w)
+	if err != nil {
+		return err
+	}
+
+	header := []export.Cell{
+		export.Text("Medarbejder"),
+		export.Text("Fra"),
+		export.Text("Til"),
+		export.Text("Forventede timer"),
+		export.Text("Fraværstimer"),
+		export.Text("Registrerede timer"),
+		export.Text("Fakturerbare timer"),
+		export.Text("Fakturerbar andel (%)"),
+		export.Text("Udnyttelse (%)"),
+		export.Text("Dage uden registreringer"),
+	}
+
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		row := []export.Cell{
+			export.Text(report.UserName),
+			export.Text(report.From),
+			export.Text(report.To),
+			export.Decimal(report.ExpectedHours.Hours()),
+			export.Decimal(report.AbsenceHours.Hours()),
+			export.Decimal(report.RegisteredHours.Hours()),
+			export.Decimal(report.BillableHours.Hours()),
+			export.Decimal(report.BillableShare * 100),
+			export.Decimal(report.Utilization * 100),
+			export.Text(strings.Join(report.MissingDays, ", ")),
+		}
+
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/gitlog"
+	"github.com/anvidev/project-time-tracker/internal/ical"
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/suggestion_rules"
+	"github.com/anvidev/project-time-tracker/internal/suggestions"
+)
+
+const (
+	maxCalendarSize int64 = 10_485_760 // 10mb
+	maxGitLogSize   int64 = 10_485_760 // 10mb
+
+	// maxSuggestionRange is the longest period suggestions are made for.
+	maxSuggestionRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrCalendarImportDisabled = errors.New("calendar files are not configured on the server")
+	ErrCalendarFileNotFound   = errors.New("calendar file not found")
+	ErrSuggestionRange        = errors.New("from must be before to and at most a year apart")
+)
+
+func (api *api) suggestionRulesList(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	rules, err := api.store.SuggestionRules.List(r.Context(), userId, r.URL.Query().Get("kind"))
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"rules": rules,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) suggestionRulesCreate(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body suggestion_rules.CreateRuleInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	rule, err := api.store.SuggestionRules.Create(r.Context(), userId, body)
+	if err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case suggestion_rules.ErrDuplicatePattern:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"rule": rule,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) suggestionRulesDelete(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if err := api.store.SuggestionRules.Delete(r.Context(), userId, id); err != nil {
+		switch err {
+		case suggestion_rules.ErrRuleNotDeleted:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// suggestionsFromCalendar suggests time entries from the timed events of a
+// calendar between ?from and ?to, both included. The calendar is the .ics
+// file in the request body, or with ?file the named file in the folder of
+// the user in the configured calendar directory.
+func (api *api) suggestionsFromCalendar(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	loc, err := time.LoadLocation(api.config.Calendar.TimeZone)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	// ?from and ?to are parsed as UTC dates, so today is the day in loc at
+	// midnight UTC
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	from, err := parseOptionalDate(r, "from", today.AddDate(0, 0, -7))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	to, err := parseOptionalDate(r, "to", today)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if to.Before(from) || to.Sub(from) > maxSuggestionRange {
+		api.badRequestError(w, r, ErrSuggestionRange)
+		return
+	}
+
+	var calendar io.Reader = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	if file := r.URL.Query().Get("file"); file != "" {
+		f, err := api.openCalendarFile(r, userId, file)
+		if err != nil {
+			switch err {
+			case ErrCalendarImportDisabled:
+				api.badRequestError(w, r, err)
+			case ErrCalendarFileNotFound:
+				api.notFoundError(w, r, err)
+			default:
+				api.internalServerError(w, r, err)
+			}
+			return
+		}
+		defer f.Close()
+
+		calendar = io.LimitReader(f, maxCalendarSize)
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+
+	events, err := ical.Events(calendar, start, end, loc)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	rules, err := api.store.SuggestionRules.List(r.Context(), userId, suggestion_rules.KindCalendar)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"suggestions": suggestions.FromEvents(events, suggestions.NewMatcher(rules)),
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+// openCalendarFile opens a file in the folder named after the email of the
+// user in the calendar directory. os.Root keeps the name from reaching
+// outside the folder.
+func (api *api) openCalendarFile(r *http.Request, userId int64, name string) (*os.File, error) {
+	if api.config.Calendar.ImportDir == "" {
+		return nil, ErrCalendarImportDisabled
+	}
+
+	user, err := api.store.Users.GetById(r.Context(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(filepath.Join(api.config.Calendar.ImportDir, filepath.Base(user.Email)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrCalendarFileNotFound
+		}
+		return nil, err
+	}
+	defer root.Close()
+
+	f, err := root.Open(name)
+	if err != nil {
+		// names escaping the folder fail like missing files
+		api.logger.Info("calendar file not opened", "userId", userId, "file", name, "error", err)
+		return nil, ErrCalendarFileNotFound
+	}
+
+	return f, nil
+}
+
+// suggestionsFromGit suggests time entries from the output of git log in the
+// request body, for commits between ?from and ?to, both included. ?author
+// keeps the commits of one author, and ?branch is used for commits whose
+// branch is not in the log.
+func (api *api) suggestionsFromGit(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	loc, err := loadTimezone(timezone)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	// ?from and ?to are parsed as UTC dates, so today is the day in loc at
+	// midnight UTC
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	from, err := parseOptionalDate(r, "from", today.AddDate(0, 0, -7))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	to, err := parseOptionalDate(r, "to", today)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	if to.Before(from) || to.Sub(from) > maxSuggestionRange {
+		api.badRequestError(w, r, ErrSuggestionRange)
+		return
+	}
+
+	commits, err := gitlog.Parse(http.MaxBytesReader(w, r.Body, maxGitLogSize))
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	var (
+		query  = r.URL.Query()
+		author = strings.ToLower(query.Get("author"))
+		first  = from.Format(time.DateOnly)
+		last   = to.Format(time.DateOnly)
+	)
+
+	commits = slices.DeleteFunc(commits, func(c gitlog.Commit) bool {
+		date := c.Date.Format(time.DateOnly)
+		if date < first || date > last {
+			return true
+		}
+		return author != "" &&
+			!strings.Contains(strings.ToLower(c.Author), author) &&
+			!strings.Contains(strings.ToLower(c.Email), author)
+	})
+
+	if branch := query.Get("branch"); branch != "" {
+		for i := range commits {
+			if commits[i].Branch == "" {
+				commits[i].Branch = branch
+			}
+		}
+	}
+
+	rules, err := api.store.SuggestionRules.List(r.Context(), userId, suggestion_rules.KindGit)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"suggestions": suggestions.FromCommits(query.Get("repository"), commits, suggestions.NewMatcher(rules)),
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -65,7 +66,16 @@ func (api *api) entriesRegisterTime(w http.ResponseWriter, r *http.Request) {
 
 	timeEntry, err := api.store.TimeEntries.Register(r.Context(), userId, body)
 	if err != nil {
-		api.internalServerError(w, r, err)
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		case time_entries.ErrTimesheetSubmitted:
+			api.conflictError(w, r, err)
+		case time_entries.ErrPeriodLocked:
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -79,6 +89,43 @@ func (api *api) entriesRegisterTime(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// entriesRegisterTimeBulk registers several entries at once, typically
+// accepted suggestions. Either all entries are registered or none.
+func (api *api) entriesRegisterTimeBulk(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body time_entries.RegisterTimeEntriesInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timeEntries, err := api.store.TimeEntries.RegisterMany(r.Context(), userId, body.Entries)
+	if err != nil {
+		switch {
+		case errors.Is(err, categories.ErrCategoryNotFound):
+			api.notFoundError(w, r, err)
+		case errors.Is(err, time_entries.ErrTimesheetSubmitted):
+			api.conflictError(w, r, err)
+		case errors.Is(err, time_entries.ErrPeriodLocked):
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timeEntries": timeEntries,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
 func (api *api) entriesUpdateTime(w http.ResponseWriter, r *http.Request) {
 	userId, _ := getUserId(r.Context())
 
@@ -97,7 +144,16 @@ func (api *api) entriesUpdateTime(w http.ResponseWriter, r *http.Request) {
 
 	timeEntry, err := api.store.TimeEntries.Update(r.Context(), userId, entryId, body)
 	if err != nil {
-		api.internalServerError(w, r, err)
+		switch err {
+		case time_entries.ErrTimeEntryNotFound:
+			api.notFoundError(w, r, err)
+		case time_entries.ErrTimesheetSubmitted:
+			api.conflictError(w, r, err)
+		case time_entries.ErrPeriodLocked:
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -183,13 +239,75 @@ func (api *api) entriesDelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := api.store.TimeEntries.Delete(r.Context(), entryId, userId); err != nil {
-		api.internalServerError(w, r, err)
+		switch err {
+		case time_entries.ErrTimeEntryNotFound:
+			api.notFoundError(w, r, err)
+		case time_entries.ErrTimesheetSubmitted:
+			api.conflictError(w, r, err)
+		case time_entries.ErrPeriodLocked:
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (api *api) entriesTrash(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	entries, err := api.store.TimeEntries.Trash(r.Context(), userId)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"timeEntries": entries,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) entriesRestore(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	entryId, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timeEntry, err := api.store.TimeEntries.Restore(r.Context(), userId, entryId)
+	if err != nil {
+		switch err {
+		case time_entries.ErrTimeEntryNotInTrash:
+			api.notFoundError(w, r, err)
+		case time_entries.ErrTimesheetSubmitted:
+			api.conflictError(w, r, err)
+		case time_entries.ErrPeriodLocked:
+			api.lockedError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timeEntry": timeEntry,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
 func (api *api) entriesFollowCategory(w http.ResponseWriter, r *http.Request) {
 	userId, _ := getUserId(r.Context())
 
@@ -203,6 +321,8 @@ func (api *api) entriesFollowCategory(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case categories.ErrAlreadyFollowed:
 			api.conflictError(w, r, err)
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
 		default:
 			api.internalServerError(w, r, err)
 		}
@@ -234,6 +354,61 @@ func (api *api) entriesUnfollowCategory(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (api *api) entriesFollowCategorySubtree(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	followed, err := api.store.Categories.FollowSubtree(r.Context(), id, userId)
+	if err != nil {
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"followed": followed,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) entriesUnfollowCategorySubtree(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	unfollowed, err := api.store.Categories.UnfollowSubtree(r.Context(), id, userId)
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"unfollowed": unfollowed,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
 func (api *api) entriesCreateCategory(w http.ResponseWriter, r *http.Request) {
 	var body categories.CreateCategoryInput
 
@@ -244,7 +419,12 @@ func (api *api) entriesCreateCategory(w http.ResponseWriter, r *http.Request) {
 
 	category, err := api.store.Categories.Create(r.Context(), body)
 	if err != nil {
-		api.internalServerError(w, r, err)
+		switch err {
+		case categories.ErrProjectNotFound:
+			api.badRequestError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -272,9 +452,14 @@ func (api *api) entriesUpdateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	category, err := api.store.Categories.Update(r.Context(), id, body.Title)
+	category, err := api.store.Categories.Update(r.Context(), id, body)
 	if err != nil {
-		api.internalServerError(w, r, err)
+		switch err {
+		case categories.ErrCategoryNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anvidev/project-time-tracker/internal/store/timesheets"
+)
+
+func (api *api) timesheetsList(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	list, err := api.store.Timesheets.List(r.Context(), timesheets.ListFilters{
+		UserId: &userId,
+		Status: r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+
+	response := map[string]any{
+		"timesheets": list,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) timesheetsSubmit(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	var body timesheets.SubmitTimesheetInput
+
+	if err := api.readJSON(w, r, &body); err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timesheet, err := api.store.Timesheets.Submit(r.Context(), userId, body)
+	if err != nil {
+		switch err {
+		case timesheets.ErrInvalidPeriod:
+			api.badRequestError(w, r, err)
+		case timesheets.ErrAlreadySubmitted:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timesheet": timesheet,
+	}
+
+	if err := api.writeJSON(w, http.StatusCreated, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
+
+func (api *api) timesheetsWithdraw(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	timesheet, err := api.store.Timesheets.Withdraw(r.Context(), userId, id)
+	if err != nil {
+		switch err {
+		case timesheets.ErrTimesheetNotFound:
+			api.notFoundError(w, r, err)
+		case timesheets.ErrInvalidTransition:
+			api.conflictError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"timesheet": timesheet,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
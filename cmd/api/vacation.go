@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/vacation"
+)
+
+func (api *api) vacationBalance(w http.ResponseWriter, r *http.Request) {
+	userId, _ := getUserId(r.Context())
+
+	date, err := parseOptionalDate(r, "date", time.Now())
+	if err != nil {
+		api.badRequestError(w, r, err)
+		return
+	}
+
+	balance, err := api.store.Vacation.Balance(r.Context(), userId, date)
+	if err != nil {
+		switch err {
+		case vacation.ErrUserNotFound:
+			api.notFoundError(w, r, err)
+		default:
+			api.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	response := map[string]any{
+		"vacation": balance,
+	}
+
+	if err := api.writeJSON(w, http.StatusOK, response); err != nil {
+		api.internalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,202 @@
+// Command gitlog suggests time entries from the commits of local
+// repositories. It runs git log in each repository, sends the output to the
+// api and prints the suggestions. With -accept the suggestions that got a
+// category from a git rule are registered.
+//
+//	gitlog -token $TOKEN -since 2026-10-12 ~/src/backend ~/src/web
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/suggestions"
+)
+
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func main() {
+	var (
+		today  = time.Now().Format(time.DateOnly)
+		apiURL = flag.String("api", envOr("TIME_TRACKER_URL", "http://localhost:9090"), "base url of the api")
+		token  = flag.String("token", os.Getenv("TIME_TRACKER_TOKEN"), "bearer token from logging in")
+		since  = flag.String("since", time.Now().AddDate(0, 0, -7).Format(time.DateOnly), "first day, YYYY-MM-DD")
+		until  = flag.String("until", today, "last day, YYYY-MM-DD")
+		author = flag.String("author", "", "author of the commits, defaults to user.email of each repository")
+		accept = flag.Bool("accept", false, "register the suggestions that have a category")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [repository ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *token == "" {
+		log.Fatal("missing -token or TIME_TRACKER_TOKEN")
+	}
+
+	repositories := flag.Args()
+	if len(repositories) == 0 {
+		repositories = []string{"."}
+	}
+
+	c := client{
+		baseURL: strings.TrimSuffix(*apiURL, "/"),
+		token:   *token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+
+	var all []suggestions.Suggestion
+	for _, repository := range repositories {
+		found, err := c.suggest(repository, *since, *until, *author)
+		if err != nil {
+			log.Fatalf("%s: %v", repository, err)
+		}
+		all = append(all, found...)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tDURATION\tCATEGORY\tSOURCE\tDESCRIPTION")
+	for _, s := range all {
+		category := s.Category
+		if s.CategoryId == nil {
+			category = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Date, s.Duration, category, s.Source, s.Description)
+	}
+	tw.Flush()
+
+	if !*accept {
+		return
+	}
+
+	var entries []time_entries.RegisterTimeEntryInput
+	for _, s := range all {
+		if s.CategoryId == nil {
+			continue
+		}
+		entries = append(entries, time_entries.RegisterTimeEntryInput{
+			CategoryId:  *s.CategoryId,
+			Date:        s.Date,
+			Duration:    s.Duration,
+			Description: s.Description,
+		})
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("no suggestions with a category to register")
+		return
+	}
+
+	body, err := json.Marshal(time_entries.RegisterTimeEntriesInput{Entries: entries})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := c.do(http.MethodPost, "/v1/me/time_entries/bulk", "application/json", bytes.NewReader(body), nil); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("registered %d of %d suggestions\n", len(entries), len(all))
+}
+
+// suggest runs git log in a repository and asks the api for suggestions.
+func (c client) suggest(repository, since, until, author string) ([]suggestions.Suggestion, error) {
+	top, err := git(repository, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	if author == "" {
+		// a repository without user.email falls back to all authors
+		author, _ = git(repository, "config", "user.email")
+	}
+
+	out, err := git(repository, "log", "--all", "--source", "--no-color", "--no-decorate",
+		"--since="+since+" 00:00:00", "--until="+until+" 23:59:59")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	query := url.Values{
+		"repository": {filepath.Base(top)},
+		"author":     {author},
+		"from":       {since},
+		"to":         {until},
+	}
+
+	var response struct {
+		Suggestions []suggestions.Suggestion `json:"suggestions"`
+	}
+	if err := c.do(http.MethodPost, "/v1/me/time_entries/suggestions/git?"+query.Encode(), "text/plain", strings.NewReader(out), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Suggestions, nil
+}
+
+func (c client) do(method, path, contentType string, body io.Reader, v any) error {
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		var envelope struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(res.Body).Decode(&envelope)
+		return fmt.Errorf("%s %s: %s %s", method, path, res.Status, envelope.Error)
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists absences (
+  id integer primary key,
+  user_id integer not null references users (id),
+  date text not null,
+  kind text not null,
+  duration text not null default "0s",
+  description text not null default ""
+);
+
+create index if not exists idx_absences_user_id_date on absences (user_id, date);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_absences_user_id_date;
+
+drop table if exists absences;
+
+-- +goose StatementEnd
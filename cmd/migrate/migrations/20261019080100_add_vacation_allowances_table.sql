@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists vacation_allowances (
+  user_id integer not null references users (id),
+  holiday_year integer not null,
+  days real not null default 25,
+  max_carry_over real not null default 5,
+  carried_over real default null,
+  primary key (user_id, holiday_year)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists vacation_allowances;
+
+-- +goose StatementEnd
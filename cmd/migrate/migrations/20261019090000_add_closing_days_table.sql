@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists closing_days (
+  id integer primary key,
+  date text unique not null,
+  name text not null
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists closing_days;
+
+-- +goose StatementEnd
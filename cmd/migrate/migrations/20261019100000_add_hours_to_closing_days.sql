@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table closing_days add column hours text default null;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table closing_days drop column hours;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists timesheets (
+  id integer primary key,
+  user_id integer not null references users (id),
+  period text not null,
+  start_date text not null,
+  end_date text not null,
+  status text not null default "open",
+  comment text not null default "",
+  submitted_at text default null,
+  reviewed_by integer references users (id) default null,
+  reviewed_at text default null,
+  unique (user_id, start_date, end_date)
+);
+
+create index if not exists idx_timesheets_user_id_dates on timesheets (user_id, start_date, end_date);
+
+create index if not exists idx_timesheets_status on timesheets (status);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_timesheets_user_id_dates;
+
+drop index if exists idx_timesheets_status;
+
+drop table if exists timesheets;
+
+-- +goose StatementEnd
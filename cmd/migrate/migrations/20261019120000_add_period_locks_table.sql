@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists period_locks (
+  id integer primary key,
+  user_id integer references users (id) default null,
+  from_date text not null,
+  to_date text not null,
+  reason text not null default "",
+  locked_by integer not null references users (id),
+  locked_at text not null,
+  unlocked_by integer references users (id) default null,
+  unlocked_at text default null
+);
+
+create index if not exists idx_period_locks_dates on period_locks (from_date, to_date);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_period_locks_dates;
+
+drop table if exists period_locks;
+
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists audit_log (
+  id integer primary key,
+  actor_id integer references users (id) default null,
+  action text not null,
+  entity text not null,
+  entity_id integer not null,
+  before text default null,
+  after text default null,
+  created_at text not null
+);
+
+create index if not exists idx_audit_log_actor_id on audit_log (actor_id);
+
+create index if not exists idx_audit_log_entity on audit_log (entity, entity_id);
+
+create index if not exists idx_audit_log_created_at on audit_log (created_at);
+
+create trigger if not exists audit_log_no_update before update on audit_log
+begin
+  select raise(abort, 'audit log is append-only');
+end;
+
+create trigger if not exists audit_log_no_delete before delete on audit_log
+begin
+  select raise(abort, 'audit log is append-only');
+end;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists audit_log_no_update;
+
+drop trigger if exists audit_log_no_delete;
+
+drop index if exists idx_audit_log_actor_id;
+
+drop index if exists idx_audit_log_entity;
+
+drop index if exists idx_audit_log_created_at;
+
+drop table if exists audit_log;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table time_entries add column deleted_at text default null;
+
+create index if not exists idx_time_entries_deleted_at on time_entries (deleted_at);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_time_entries_deleted_at;
+
+alter table time_entries drop column deleted_at;
+
+-- +goose StatementEnd
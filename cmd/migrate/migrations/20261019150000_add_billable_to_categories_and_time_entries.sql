@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table categories add column is_billable integer not null default 0;
+
+alter table time_entries add column billable integer not null default 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table time_entries drop column billable;
+
+alter table categories drop column is_billable;
+
+-- +goose StatementEnd
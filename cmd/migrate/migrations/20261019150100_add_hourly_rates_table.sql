@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists hourly_rates (
+  id integer primary key,
+  category_id integer references categories (id) default null,
+  user_id integer references users (id) default null,
+  rate real not null,
+  effective_from text not null,
+  check (category_id is not null or user_id is not null)
+);
+
+create index if not exists idx_hourly_rates_category_id on hourly_rates (category_id);
+
+create index if not exists idx_hourly_rates_user_id on hourly_rates (user_id);
+
+create unique index if not exists idx_hourly_rates_scope on hourly_rates (
+  coalesce(category_id, 0),
+  coalesce(user_id, 0),
+  effective_from
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_hourly_rates_category_id;
+
+drop index if exists idx_hourly_rates_user_id;
+
+drop index if exists idx_hourly_rates_scope;
+
+drop table if exists hourly_rates;
+
+-- +goose StatementEnd
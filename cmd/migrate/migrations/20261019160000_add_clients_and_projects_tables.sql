@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists clients (
+  id integer primary key,
+  name text not null,
+  code text not null unique,
+  is_active integer not null default 1
+);
+
+create table if not exists projects (
+  id integer primary key,
+  client_id integer references clients (id) default null,
+  name text not null,
+  code text not null unique,
+  is_active integer not null default 1,
+  budget real default null,
+  owner_id integer references users (id) default null
+);
+
+create index if not exists idx_projects_client_id on projects (client_id);
+
+-- not declared as a foreign key, so the down migration can drop the column
+-- without rebuilding categories; the project is checked when it is set
+alter table categories add column project_id integer default null;
+
+create index if not exists idx_categories_project_id on categories (project_id);
+
+-- every existing root category becomes a project owning its subtree
+insert into projects (name, code)
+select title, 'P-' || id
+from categories
+where parent_id is null;
+
+with recursive tree (id, root_id) as (
+  select id, id from categories where parent_id is null
+  union all
+  select c.id, t.root_id from categories c
+  inner join tree t on c.parent_id = t.id
+)
+update categories
+set project_id = (
+  select p.id
+  from tree t
+  inner join projects p on p.code = 'P-' || t.root_id
+  where t.id = categories.id
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_categories_project_id;
+
+alter table categories drop column project_id;
+
+drop index if exists idx_projects_client_id;
+
+drop table if exists projects;
+
+drop table if exists clients;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table categories add column budget text default null;
+
+alter table categories add column budget_alert integer not null default 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table categories drop column budget_alert;
+
+alter table categories drop column budget;
+
+-- +goose StatementEnd
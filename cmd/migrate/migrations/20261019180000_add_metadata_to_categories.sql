@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table categories add column code text default null;
+
+alter table categories add column description text not null default '';
+
+alter table categories add column color text default null;
+
+alter table categories add column sort_order integer not null default 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table categories drop column sort_order;
+
+alter table categories drop column color;
+
+alter table categories drop column description;
+
+alter table categories drop column code;
+
+-- +goose StatementEnd
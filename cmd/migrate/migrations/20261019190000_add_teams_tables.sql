@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists teams (
+  id integer primary key,
+  name text not null unique
+);
+
+create table if not exists team_members (
+  team_id integer not null references teams (id),
+  user_id integer not null references users (id),
+  primary key (team_id, user_id)
+);
+
+create index if not exists idx_team_members_user_id on team_members (user_id);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_team_members_user_id;
+
+drop table if exists team_members;
+
+drop table if exists teams;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table categories add column visibility text not null default 'everyone';
+
+create table if not exists category_access (
+  category_id integer not null references categories (id),
+  user_id integer references users (id) default null,
+  team_id integer references teams (id) default null,
+  check ((user_id is null) != (team_id is null))
+);
+
+create unique index if not exists idx_category_access_unique on category_access (
+  category_id,
+  coalesce(user_id, 0),
+  coalesce(team_id, 0)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_category_access_unique;
+
+drop table if exists category_access;
+
+alter table categories drop column visibility;
+
+-- +goose StatementEnd
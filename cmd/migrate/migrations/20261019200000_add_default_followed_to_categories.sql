@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table categories add column is_default_followed integer not null default 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table categories drop column is_default_followed;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table time_entries add column duration_seconds integer not null default 0;
+
+-- durations are stored as Go duration strings such as 7h30m0s, 45m0s or 0s.
+-- Durations below a second are written with an ms, µs or ns unit instead,
+-- e.g. 500ms, and are not parsed but counted as 0 seconds. Fractions of a
+-- second are dropped, like int64(d.Seconds()) does when entries are written.
+update time_entries
+set duration_seconds = case
+  when duration like '%ms' or duration like '%µs' or duration like '%us' or duration like '%ns'
+    then 0
+  else cast(
+    case when instr(duration, 'h') > 0
+      then cast(substr(duration, 1, instr(duration, 'h') - 1) as integer) * 3600
+      else 0
+    end
+    + case when instr(duration, 'm') > 0
+      then cast(substr(duration, instr(duration, 'h') + 1, instr(duration, 'm') - instr(duration, 'h') - 1) as integer) * 60
+      else 0
+    end
+    + case when instr(duration, 'm') > 0
+      then cast(substr(duration, instr(duration, 'm') + 1) as real)
+      else cast(substr(duration, instr(duration, 'h') + 1) as real)
+    end
+  as integer)
+end;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table time_entries drop column duration_seconds;
+
+-- +goose StatementEnd
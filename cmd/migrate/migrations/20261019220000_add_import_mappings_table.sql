@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists import_mappings (
+  id integer primary key,
+  source text not null,
+  kind text not null check (kind in ('category', 'user')),
+  external_key text not null,
+  category_id integer references categories (id),
+  user_id integer references users (id),
+  updated_at text not null
+);
+
+create unique index if not exists idx_import_mappings_key on import_mappings (source, kind, external_key);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_import_mappings_key;
+
+drop table if exists import_mappings;
+
+-- +goose StatementEnd
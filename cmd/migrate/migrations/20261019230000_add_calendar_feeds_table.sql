@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists calendar_feeds (
+  user_id integer primary key references users (id),
+  token text not null unique,
+  created_at text not null
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists calendar_feeds;
+
+-- +goose StatementEnd
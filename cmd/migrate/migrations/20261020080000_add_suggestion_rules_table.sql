@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists suggestion_rules (
+  id integer primary key,
+  user_id integer not null references users (id),
+  kind text not null check (kind in ('calendar', 'git')),
+  pattern text not null,
+  category_id integer not null references categories (id),
+  created_at text not null
+);
+
+create unique index if not exists idx_suggestion_rules_pattern on suggestion_rules (user_id, kind, pattern);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists idx_suggestion_rules_pattern;
+
+drop table if exists suggestion_rules;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists report_subscriptions (
+  id integer primary key,
+  recipient text not null,
+  report text not null check (report in ('summary', 'utilization')),
+  span text not null check (span in ('day', 'week', 'month')),
+  filters text not null default '',
+  schedule text not null,
+  attach_csv boolean not null default 0,
+  created_by integer not null references users (id),
+  created_at text not null,
+  last_sent_at text
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists report_subscriptions;
+
+-- +goose StatementEnd
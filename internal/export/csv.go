@@ -0,0 +1,39 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// NewCSV writes semicolon separated values with decimal commas and a byte
+// order mark, which is what Excel with Danish regional settings opens
+// directly.
+func NewCSV(w io.Writer) (Writer, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+	cw.UseCRLF = true
+
+	return &csvWriter{w: cw}, nil
+}
+
+func (c *csvWriter) Write(row []Cell) error {
+	c.record = c.record[:0]
+	for _, cell := range row {
+		c.record = append(c.record, cell.danish())
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
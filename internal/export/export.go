@@ -0,0 +1,90 @@
+// Package export writes tabular data as CSV or XLSX one row at a time, so
+// large results can be streamed to a client without buffering them.
+package export
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV  string = "csv"
+	FormatXLSX        = "xlsx"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format, must be csv or xlsx")
+)
+
+type cellKind int
+
+const (
+	kindText cellKind = iota
+	kindInteger
+	kindDecimal
+)
+
+// Cell is a single value in a row. Numbers are kept as numbers so XLSX
+// files get numeric cells and CSV files get locale formatting.
+type Cell struct {
+	kind   cellKind
+	text   string
+	number float64
+}
+
+func Text(s string) Cell {
+	return Cell{kind: kindText, text: s}
+}
+
+func Integer(n int64) Cell {
+	return Cell{kind: kindInteger, number: float64(n)}
+}
+
+// Decimal is a number shown with two decimals.
+func Decimal(n float64) Cell {
+	return Cell{kind: kindDecimal, number: n}
+}
+
+// danish formats the cell the way Danish spreadsheets expect it, with a
+// decimal comma.
+func (c Cell) danish() string {
+	switch c.kind {
+	case kindInteger:
+		return strconv.FormatInt(int64(c.number), 10)
+	case kindDecimal:
+		return strings.Replace(strconv.FormatFloat(c.number, 'f', 2, 64), ".", ",", 1)
+	default:
+		return c.text
+	}
+}
+
+type Writer interface {
+	// Write adds a row. The first row is written as the header.
+	Write(row []Cell) error
+	// Close flushes the remaining output. It does not close the underlying
+	// io.Writer.
+	Close() error
+}
+
+// New returns a writer for format, one of FormatCSV or FormatXLSX.
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(w)
+	case FormatXLSX:
+		return NewXLSX(w, "Data")
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The parts of a workbook with a single sheet. Style 1 shows numbers with
+// two decimals and style 2 makes the header bold.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="3">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`</cellXfs>` +
+		`</styleSheet>`},
+}
+
+type xlsxWriter struct {
+	zw   *zip.Writer
+	w    *bufio.Writer
+	rows int
+}
+
+// NewXLSX writes a workbook with a single sheet. The sheet is the last part
+// of the archive, so rows are compressed and written as they arrive.
+func NewXLSX(w io.Writer, sheet string) (Writer, error) {
+	zw := zip.NewWriter(w)
+
+	workbook := xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + escape(sheet) + `" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+
+	f, err := zw.Create("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(f, workbook); err != nil {
+		return nil, err
+	}
+
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err = zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xlsxWriter{zw: zw, w: bufio.NewWriter(f)}
+
+	x.w.WriteString(xml.Header)
+	x.w.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return x, nil
+}
+
+func (x *xlsxWriter) Write(row []Cell) error {
+	x.rows++
+	rowRef := strconv.Itoa(x.rows)
+
+	x.w.WriteString(`<row r="` + rowRef + `">`)
+
+	for i, cell := range row {
+		ref := columnName(i) + rowRef
+
+		switch {
+		case cell.kind == kindText && x.rows == 1:
+			x.w.WriteString(`<c r="` + ref + `" s="2" t="inlineStr"><is><t>` + escape(cell.text) + `</t></is></c>`)
+		case cell.kind == kindText:
+			x.w.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + escape(cell.text) + `</t></is></c>`)
+		case cell.kind == kindDecimal:
+			x.w.WriteString(`<c r="` + ref + `" s="1"><v>` + strconv.FormatFloat(cell.number, 'f', -1, 64) + `</v></c>`)
+		default:
+			x.w.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(cell.number, 'f', -1, 64) + `</v></c>`)
+		}
+	}
+
+	_, err := x.w.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	x.w.WriteString(`</sheetData></worksheet>`)
+
+	if err := x.w.Flush(); err != nil {
+		return err
+	}
+
+	return x.zw.Close()
+}
+
+// columnName returns the spreadsheet name of the zero based column i, such
+// as A, Z or AA.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,142 @@
+// Package gitlog reads the output of git log, so commits can be turned into
+// suggested time entries.
+package gitlog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoCommits   = errors.New("no commits found, use the default format of git log")
+	ErrInvalidDate = errors.New("invalid commit date")
+)
+
+// dateLayouts are the layouts of the Date line with git log's default,
+// --date=iso and --date=iso-strict.
+var dateLayouts = []string{
+	"Mon Jan 2 15:04:05 2006 -0700",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC3339,
+}
+
+type Commit struct {
+	Hash    string
+	Branch  string // from --source, empty without it
+	Author  string
+	Email   string
+	Date    time.Time // in the time zone of the author
+	Subject string
+	Merge   bool
+}
+
+// Parse reads commits in the default (medium) format of git log. With
+// --source the branch each commit was reached from is read as well, e.g.
+//
+//	git log --all --source --author=me@example.com --since=2.weeks
+//
+// Decorations, --stat output and commit bodies are skipped.
+func Parse(r io.Reader) ([]Commit, error) {
+	var (
+		commits []Commit
+		current *Commit
+		lineNum int
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if hash, ok := strings.CutPrefix(line, "commit "); ok {
+			commits = append(commits, parseCommitLine(hash))
+			current = &commits[len(commits)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "Merge:"):
+			current.Merge = true
+		case strings.HasPrefix(line, "Author:"):
+			current.Author, current.Email = parseAuthor(strings.TrimPrefix(line, "Author:"))
+		case strings.HasPrefix(line, "Date:"):
+			date, err := parseDate(strings.TrimPrefix(line, "Date:"))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			current.Date = date
+		case strings.HasPrefix(line, "    ") && current.Subject == "":
+			current.Subject = strings.TrimSpace(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(commits) == 0 {
+		return nil, ErrNoCommits
+	}
+
+	for _, commit := range commits {
+		if commit.Date.IsZero() {
+			return nil, fmt.Errorf("commit %s: %w", commit.Hash, ErrInvalidDate)
+		}
+	}
+
+	return commits, nil
+}
+
+// parseCommitLine reads the hash and, with --source, the ref of a commit
+// line such as "commit 1a2b3c\trefs/heads/main (HEAD -> main)".
+func parseCommitLine(value string) Commit {
+	value, _, _ = strings.Cut(value, " (")
+	hash, source, _ := strings.Cut(value, "\t")
+
+	return Commit{
+		Hash:   strings.TrimSpace(hash),
+		Branch: branchName(strings.TrimSpace(source)),
+	}
+}
+
+// branchName strips the refs/heads/ or refs/remotes/<remote>/ prefix of a
+// ref. Other refs, such as tags, are returned as is.
+func branchName(ref string) string {
+	if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return branch
+	}
+	if remote, ok := strings.CutPrefix(ref, "refs/remotes/"); ok {
+		if _, branch, ok := strings.Cut(remote, "/"); ok {
+			return branch
+		}
+	}
+	return ref
+}
+
+// parseAuthor splits "Name <email>".
+func parseAuthor(value string) (string, string) {
+	name, email, ok := strings.Cut(value, "<")
+	if !ok {
+		return strings.TrimSpace(value), ""
+	}
+	return strings.TrimSpace(name), strings.TrimSuffix(strings.TrimSpace(email), ">")
+}
+
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, value)
+}
@@ -0,0 +1,93 @@
+package gitlog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// summary writes the fields of a commit on a single line, with the date as
+// written by its author.
+func summary(c Commit) string {
+	return fmt.Sprintf("%.7s %s %s <%s> %s %q merge=%t",
+		c.Hash, c.Branch, c.Author, c.Email, c.Date.Format(time.RFC3339), c.Subject, c.Merge)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "testdata/default.log",
+			want: []string{
+				`9f86d08  Jane Doe <jane@example.com> 2026-10-19T16:05:12+02:00 "Add CSV export of time entries" merge=false`,
+				`2c26b46  John Doe <john@example.com> 2026-10-16T09:30:00+02:00 "Fix rounding of decimal hours" merge=false`,
+			},
+		},
+		{
+			file: "testdata/source.log",
+			want: []string{
+				`5e88489 feature/export Jane Doe <jane@example.com> 2026-10-19T17:00:00+02:00 "Merge branch 'main' into feature/export" merge=true`,
+				`9f86d08 main Jane Doe <jane@example.com> 2026-10-19T16:05:12+02:00 "Add CSV export of time entries" merge=false`,
+				`2c26b46 refs/tags/v1.2.0 John Doe <> 2026-10-16T09:30:00+02:00 "Fix rounding of decimal hours" merge=false`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		f, err := os.Open(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commits, err := Parse(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", tt.file, err)
+		}
+
+		var got []string
+		for _, c := range commits {
+			got = append(got, summary(c))
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%s) =\n%s\nwant\n%s", tt.file, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
+
+func TestParseCRLF(t *testing.T) {
+	log := "commit 9f86d08\r\nAuthor: Jane Doe <jane@example.com>\r\nDate:   Mon Oct 19 16:05:12 2026 +0200\r\n\r\n    Add CSV export\r\n"
+
+	commits, err := Parse(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commits[0].Email != "jane@example.com" || commits[0].Subject != "Add CSV export" {
+		t.Errorf("Parse() = %+v", commits[0])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		log  string
+		want error
+	}{
+		{"", ErrNoCommits},
+		{"9f86d08 Add CSV export of time entries\n", ErrNoCommits},
+		{"commit 9f86d08\nAuthor: Jane Doe <jane@example.com>\nDate:   2 days ago\n", ErrInvalidDate},
+		{"commit 9f86d08\nAuthor: Jane Doe <jane@example.com>\n\n    Add CSV export\n", ErrInvalidDate},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(strings.NewReader(tt.log)); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.log, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package holidays
+
+import "time"
+
+func init() {
+	Register(NewRuleSet("DK",
+		Rule{Name: "Nytårsdag", Date: Fixed(time.January, 1)},
+		Rule{Name: "Skærtorsdag", Date: EasterOffset(-3)},
+		Rule{Name: "Langfredag", Date: EasterOffset(-2)},
+		Rule{Name: "Påskedag", Date: EasterOffset(0)},
+		Rule{Name: "2. påskedag", Date: EasterOffset(1)},
+		Rule{Name: "Store bededag", Date: Until(2023, EasterOffset(26))}, // abolished from 2024
+		Rule{Name: "Kristi himmelfartsdag", Date: EasterOffset(39)},
+		Rule{Name: "Pinsedag", Date: EasterOffset(49)},
+		Rule{Name: "2. pinsedag", Date: EasterOffset(50)},
+		Rule{Name: "Juledag", Date: Fixed(time.December, 25)},
+		Rule{Name: "2. juledag", Date: Fixed(time.December, 26)},
+	))
+}
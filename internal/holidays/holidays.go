@@ -0,0 +1,98 @@
+// Package holidays calculates public holidays from per-country rule sets, so
+// no external calendar service is needed to know if a date is a day off.
+package holidays
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+const (
+	KindPublic  string = "public"
+	KindClosing        = "closing"
+)
+
+var (
+	ErrUnknownCountry = errors.New("no holiday rules for country")
+)
+
+type Holiday struct {
+	Date string `json:"date"` // yyyy-MM-dd (time.DateOnly)
+	Name string `json:"name"`
+	Kind string `json:"kind" apiduck:"desc=public for public holidays and closing for company closing days"`
+	// Hours is the reduced working time on the day, or nil if it is a day off.
+	Hours *types.Duration `json:"hours,omitempty"`
+}
+
+// IsDayOff reports whether no work is expected on the holiday.
+func (h Holiday) IsDayOff() bool {
+	return h.Hours == nil
+}
+
+// RuleSet provides the public holidays of a single country.
+type RuleSet interface {
+	Country() string
+	Holidays(year int) []Holiday
+}
+
+var (
+	mu       sync.RWMutex
+	ruleSets = make(map[string]RuleSet)
+)
+
+// Register makes a rule set available through Lookup. Registering a rule set
+// for a country that already has one replaces it.
+func Register(rs RuleSet) {
+	mu.Lock()
+	defer mu.Unlock()
+	ruleSets[strings.ToUpper(rs.Country())] = rs
+}
+
+// Lookup returns the rule set for an ISO 3166-1 alpha-2 country code.
+func Lookup(country string) (RuleSet, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	rs, ok := ruleSets[strings.ToUpper(country)]
+	if !ok {
+		return nil, ErrUnknownCountry
+	}
+	return rs, nil
+}
+
+// Countries returns the country codes that have a registered rule set.
+func Countries() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	countries := make([]string, 0, len(ruleSets))
+	for country := range ruleSets {
+		countries = append(countries, country)
+	}
+	slices.Sort(countries)
+
+	return countries
+}
+
+// Find returns the holiday on date, if any.
+func Find(rs RuleSet, date time.Time) (Holiday, bool) {
+	dateString := date.Format(time.DateOnly)
+	for _, holiday := range rs.Holidays(date.Year()) {
+		if holiday.Date == dateString {
+			return holiday, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// Sort orders holidays by date.
+func Sort(list []Holiday) {
+	slices.SortStableFunc(list, func(a, b Holiday) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+}
@@ -0,0 +1,61 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want string
+	}{
+		{1818, "1818-03-22"}, // earliest possible date
+		{1943, "1943-04-25"}, // latest possible date
+		{2000, "2000-04-23"},
+		{2019, "2019-04-21"},
+		{2023, "2023-04-09"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2026, "2026-04-05"},
+		{2038, "2038-04-25"},
+	}
+
+	for _, tt := range tests {
+		if got := Easter(tt.year).Format(time.DateOnly); got != tt.want {
+			t.Errorf("Easter(%d) = %s, want %s", tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestDenmark(t *testing.T) {
+	rs, err := Lookup("DK")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date  string
+		name  string
+		found bool
+	}{
+		{"2023-04-06", "Skærtorsdag", true},
+		{"2023-05-05", "Store bededag", true},
+		{"2023-05-18", "Kristi himmelfartsdag", true},
+		{"2024-04-26", "", false}, // Store bededag was abolished from 2024
+		{"2026-04-03", "Langfredag", true},
+		{"2026-04-06", "2. påskedag", true},
+		{"2026-05-25", "2. pinsedag", true},
+		{"2026-12-24", "", false},
+		{"2026-12-26", "2. juledag", true},
+	}
+
+	for _, tt := range tests {
+		date, _ := time.Parse(time.DateOnly, tt.date)
+
+		holiday, ok := Find(rs, date)
+		if ok != tt.found || holiday.Name != tt.name {
+			t.Errorf("Find(DK, %s) = %q, %v, want %q, %v", tt.date, holiday.Name, ok, tt.name, tt.found)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package holidays
+
+import (
+	"time"
+)
+
+// Rule describes a single recurring holiday. Date returns false when the
+// holiday is not observed in the given year.
+type Rule struct {
+	Name string
+	Date func(year int) (time.Time, bool)
+}
+
+type rules struct {
+	country string
+	rules   []Rule
+}
+
+// NewRuleSet creates a rule set for country from a list of rules.
+func NewRuleSet(country string, list ...Rule) RuleSet {
+	return &rules{country: country, rules: list}
+}
+
+func (r *rules) Country() string {
+	return r.country
+}
+
+func (r *rules) Holidays(year int) []Holiday {
+	list := make([]Holiday, 0, len(r.rules))
+	for _, rule := range r.rules {
+		date, ok := rule.Date(year)
+		if !ok {
+			continue
+		}
+		list = append(list, Holiday{
+			Date: date.Format(time.DateOnly),
+			Name: rule.Name,
+			Kind: KindPublic,
+		})
+	}
+	Sort(list)
+	return list
+}
+
+// Fixed is a holiday on the same date every year.
+func Fixed(month time.Month, day int) func(int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+	}
+}
+
+// EasterOffset is a holiday a number of days before or after Easter Sunday.
+func EasterOffset(days int) func(int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		return Easter(year).AddDate(0, 0, days), true
+	}
+}
+
+// WeekdayBetween is a holiday on the first weekday on or after month and day,
+// e.g. the Saturday between 31 October and 6 November.
+func WeekdayBetween(weekday time.Weekday, month time.Month, day int) func(int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(date.Weekday()) + 7) % 7
+		return date.AddDate(0, 0, offset), true
+	}
+}
+
+// Until limits a holiday to the years up to and including last.
+func Until(last int, date func(int) (time.Time, bool)) func(int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		if year > last {
+			return time.Time{}, false
+		}
+		return date(year)
+	}
+}
+
+// Easter returns Easter Sunday of the Gregorian calendar using the anonymous
+// Gregorian algorithm (Meeus/Jones/Butcher).
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
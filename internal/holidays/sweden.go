@@ -0,0 +1,21 @@
+package holidays
+
+import "time"
+
+func init() {
+	Register(NewRuleSet("SE",
+		Rule{Name: "Nyårsdagen", Date: Fixed(time.January, 1)},
+		Rule{Name: "Trettondedag jul", Date: Fixed(time.January, 6)},
+		Rule{Name: "Långfredagen", Date: EasterOffset(-2)},
+		Rule{Name: "Påskdagen", Date: EasterOffset(0)},
+		Rule{Name: "Annandag påsk", Date: EasterOffset(1)},
+		Rule{Name: "Första maj", Date: Fixed(time.May, 1)},
+		Rule{Name: "Kristi himmelsfärdsdag", Date: EasterOffset(39)},
+		Rule{Name: "Pingstdagen", Date: EasterOffset(49)},
+		Rule{Name: "Sveriges nationaldag", Date: Fixed(time.June, 6)},
+		Rule{Name: "Midsommardagen", Date: WeekdayBetween(time.Saturday, time.June, 20)},
+		Rule{Name: "Alla helgons dag", Date: WeekdayBetween(time.Saturday, time.October, 31)},
+		Rule{Name: "Juldagen", Date: Fixed(time.December, 25)},
+		Rule{Name: "Annandag jul", Date: Fixed(time.December, 26)},
+	))
+}
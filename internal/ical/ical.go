@@ -0,0 +1,120 @@
+// Package ical writes iCalendar (RFC 5545) feeds that calendar clients can
+// subscribe to. Timed events use floating local times, so they are shown
+// at the same hour whatever the time zone of the client.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+
+	// maxLineLength is the longest content line in octets before it is
+	// folded onto the next line.
+	maxLineLength = 75
+)
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is an all-day event on the date of Start when AllDay is set, and
+// otherwise an event from Start to End.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// Write writes the calendar with every event stamped with the current time.
+func Write(w io.Writer, calendar Calendar) error {
+	out := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(dateTimeLayout) + "Z"
+
+	line := func(name, value string) {
+		writeLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Project Time Tracker//DA")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escape(calendar.Name))
+
+	for _, event := range calendar.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", stamp)
+
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE", event.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE", event.Start.AddDate(0, 0, 1).Format(dateLayout))
+			line("TRANSP", "TRANSPARENT")
+		} else {
+			line("DTSTART", event.Start.Format(dateTimeLayout))
+			line("DTEND", event.End.Format(dateTimeLayout))
+		}
+
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	return out.Flush()
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escape escapes a text value.
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// writeLine writes a content line ended by CRLF, folding it into lines of
+// at most 75 octets without splitting characters.
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts
+		limit = maxLineLength - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,496 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotCalendar = errors.New("the file is not an iCalendar file")
+	ErrInvalidDate = errors.New("invalid date in calendar")
+)
+
+// maxOccurrences bounds the expansion of a recurring event. Occurrences
+// before the requested range do not count.
+const maxOccurrences = 5000
+
+// property is a content line, e.g. DTSTART;TZID=Europe/Copenhagen:20261019T090000.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// vevent is an event as read, before recurrences are expanded.
+type vevent struct {
+	Event
+	rrule        string
+	exdates      []time.Time
+	recurrenceId *time.Time
+	cancelled    bool
+}
+
+// Events reads the events of a calendar that overlap [from, to), expanding
+// recurring events. Times without a time zone, and times in zones that are
+// unknown on this system, are read in loc. Cancelled events are left out.
+//
+// Recurrence rules with FREQ=DAILY, WEEKLY, MONTHLY or YEARLY and the parts
+// INTERVAL, COUNT, UNTIL and, for daily and weekly rules, BYDAY are
+// supported. Rules with other BY parts, such as BYMONTHDAY or BYSETPOS, are
+// not expanded and only the first occurrence is read.
+func Events(r io.Reader, from, to time.Time, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrNotCalendar
+	}
+
+	var (
+		events  []*vevent
+		current *vevent
+		depth   int // components nested in the current event, such as alarms
+	)
+
+	for _, line := range lines {
+		prop := parseProperty(line)
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && current == nil:
+			current = &vevent{}
+			continue
+		case prop.name == "BEGIN" && current != nil:
+			depth++
+			continue
+		case prop.name == "END" && current != nil && depth > 0:
+			depth--
+			continue
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT") && current != nil:
+			events = append(events, current)
+			current = nil
+			continue
+		}
+
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		if err := current.set(prop, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	// instances moved or changed by a RECURRENCE-ID replace the occurrence
+	// of the recurring event they were generated from
+	overridden := map[string]bool{}
+	for _, event := range events {
+		if event.recurrenceId != nil {
+			overridden[event.UID+"/"+event.recurrenceId.Format(dateTimeLayout)] = true
+		}
+	}
+
+	var result []Event
+
+	for _, event := range events {
+		if event.cancelled {
+			continue
+		}
+
+		if event.End.IsZero() {
+			event.End = event.Start
+			if event.AllDay {
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+		}
+
+		for _, occurrence := range event.occurrences(from, to) {
+			if event.recurrenceId == nil && event.rrule != "" && overridden[event.UID+"/"+occurrence.Start.Format(dateTimeLayout)] {
+				continue
+			}
+
+			if occurrence.End.After(from) && occurrence.Start.Before(to) {
+				result = append(result, occurrence)
+			}
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b Event) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return result, nil
+}
+
+func (e *vevent) set(prop property, loc *time.Location) error {
+	var err error
+
+	switch prop.name {
+	case "UID":
+		e.UID = prop.value
+	case "SUMMARY":
+		e.Summary = unescape(prop.value)
+	case "DESCRIPTION":
+		e.Description = unescape(prop.value)
+	case "CATEGORIES":
+		for _, category := range splitList(prop.value) {
+			e.Categories = append(e.Categories, unescape(category))
+		}
+	case "STATUS":
+		e.cancelled = strings.EqualFold(prop.value, "CANCELLED")
+	case "DTSTART":
+		e.Start, e.AllDay, err = parseTime(prop, loc)
+	case "DTEND":
+		e.End, _, err = parseTime(prop, loc)
+	case "DURATION":
+		var d time.Duration
+		if d, err = parseDuration(prop.value); err == nil && !e.Start.IsZero() {
+			e.End = e.Start.Add(d)
+		}
+	case "RRULE":
+		e.rrule = prop.value
+	case "EXDATE":
+		for _, value := range splitList(prop.value) {
+			exdate, _, err := parseTime(property{params: prop.params, value: value}, loc)
+			if err != nil {
+				return err
+			}
+			e.exdates = append(e.exdates, exdate)
+		}
+	case "RECURRENCE-ID":
+		var id time.Time
+		if id, _, err = parseTime(prop, loc); err == nil {
+			e.recurrenceId = &id
+		}
+	}
+
+	return err
+}
+
+// occurrences returns the event itself, or every occurrence of a recurring
+// event that ends after from and starts before until.
+func (e *vevent) occurrences(from, until time.Time) []Event {
+	if e.rrule == "" || e.recurrenceId != nil {
+		return []Event{e.Event}
+	}
+
+	rule := map[string]string{}
+	for _, part := range strings.Split(e.rrule, ";") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			rule[strings.ToUpper(key)] = value
+		}
+	}
+
+	freq := strings.ToUpper(rule["FREQ"])
+	for key := range rule {
+		if !strings.HasPrefix(key, "BY") {
+			continue
+		}
+		if key != "BYDAY" || (freq != "DAILY" && freq != "WEEKLY") {
+			// expanding the rule without the part would make up
+			// occurrences
+			return []Event{e.Event}
+		}
+	}
+
+	interval, _ := strconv.Atoi(rule["INTERVAL"])
+	interval = max(interval, 1)
+
+	count, _ := strconv.Atoi(rule["COUNT"])
+
+	if value, ok := rule["UNTIL"]; ok {
+		if end, _, err := parseTime(property{value: value}, e.Start.Location()); err == nil {
+			if end.Before(until) {
+				// UNTIL is inclusive
+				until = end.Add(time.Second)
+			}
+		}
+	}
+
+	weekdays := parseWeekdays(rule["BYDAY"])
+	duration := e.End.Sub(e.Start)
+
+	var (
+		result []Event
+		n      int // occurrences so far, including excluded ones, for COUNT
+		read   int // occurrences in range so far, for maxOccurrences
+	)
+
+	add := func(start time.Time) bool {
+		if !start.Before(until) || (count > 0 && n >= count) || read >= maxOccurrences {
+			return false
+		}
+		n++
+
+		if !start.Add(duration).After(from) {
+			return true
+		}
+		read++
+
+		if !slices.ContainsFunc(e.exdates, start.Equal) {
+			occurrence := e.Event
+			occurrence.UID = e.UID + "/" + start.Format(dateTimeLayout)
+			occurrence.Start = start
+			occurrence.End = start.Add(duration)
+			result = append(result, occurrence)
+		}
+
+		return true
+	}
+
+	switch freq {
+	case "DAILY":
+		for i := 0; ; i += interval {
+			start := e.Start.AddDate(0, 0, i)
+			if len(weekdays) > 0 && !slices.Contains(weekdays, start.Weekday()) {
+				if !start.Before(until) {
+					break
+				}
+				continue
+			}
+			if !add(start) {
+				break
+			}
+		}
+	case "WEEKLY":
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{e.Start.Weekday()}
+		}
+		// weeks start on monday
+		monday := e.Start.AddDate(0, 0, -((int(e.Start.Weekday()) + 6) % 7))
+	weeks:
+		for week := 0; ; week += interval {
+			for day := range 7 {
+				start := monday.AddDate(0, 0, 7*week+day)
+				if start.Before(e.Start) || !slices.Contains(weekdays, start.Weekday()) {
+					continue
+				}
+				if !add(start) {
+					break weeks
+				}
+			}
+		}
+	case "MONTHLY":
+		for i := 0; ; i += interval {
+			start := e.Start.AddDate(0, i, 0)
+			if start.Day() != e.Start.Day() {
+				// months without the day are skipped
+				if !start.Before(until) {
+					break
+				}
+				continue
+			}
+			if !add(start) {
+				break
+			}
+		}
+	case "YEARLY":
+		for i := 0; ; i += interval {
+			if !add(e.Start.AddDate(i, 0, 0)) {
+				break
+			}
+		}
+	default:
+		return []Event{e.Event}
+	}
+
+	return result
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// parseWeekdays reads BYDAY, ignoring ordinals such as the 1 in 1MO.
+func parseWeekdays(value string) []time.Weekday {
+	var weekdays []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if len(code) < 2 {
+			continue
+		}
+		if weekday, ok := weekdayCodes[code[len(code)-2:]]; ok {
+			weekdays = append(weekdays, weekday)
+		}
+	}
+	return weekdays
+}
+
+// unfold reads the content lines of a calendar, joining folded lines.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+func parseProperty(line string) property {
+	prop := property{params: map[string]string{}}
+
+	// the value starts at the first colon outside quoted parameter values
+	quoted := false
+	end := len(line)
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			end = i
+			break
+		}
+	}
+
+	if end < len(line) {
+		prop.value = line[end+1:]
+	}
+
+	parts := strings.Split(line[:end], ";")
+	prop.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return prop
+}
+
+// parseTime reads a DATE or DATE-TIME value and reports whether it is a
+// date.
+func parseTime(prop property, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if prop.params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		date, err := time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidDate, value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, strings.TrimSuffix(value, "Z"))
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidDate, value)
+		}
+		return t.In(loc), false, nil
+	}
+
+	zone := loc
+	if tzid, ok := prop.params["TZID"]; ok {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			zone = tz
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, zone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidDate, value)
+	}
+
+	return t.In(loc), false, nil
+}
+
+// parseDuration reads durations such as PT1H30M, P1D or P1W. Negative
+// durations are not used for events and are rejected.
+func parseDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(value, "+"), "P")
+	if !ok {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+	}
+
+	var (
+		d      time.Duration
+		number string
+	)
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+		case c >= '0' && c <= '9':
+			number += string(c)
+		default:
+			unit, ok := units[c]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("invalid duration: %s", value)
+			}
+			d += time.Duration(n) * unit
+			number = ""
+		}
+	}
+
+	return d, nil
+}
+
+var unescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, `;`,
+	`\,`, `,`,
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+func unescape(value string) string {
+	return unescaper.Replace(value)
+}
+
+// splitList splits a comma separated value on commas that are not escaped.
+func splitList(value string) []string {
+	var (
+		parts   []string
+		current strings.Builder
+	)
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	return append(parts, current.String())
+}
@@ -0,0 +1,67 @@
+package ical
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	calendar, err := os.ReadFile("testdata/recurrence.ics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		uid      string
+		from, to string // yyyy-MM-dd, to is excluded
+		want     []string
+	}{
+		{"single", "2026-10-19", "2026-10-20", []string{"2026-10-19 09:00"}},
+		{"single", "2026-10-20", "2026-10-21", []string{}},
+		// COUNT is counted from DTSTART, not from the start of the range
+		{"daily-count", "2026-10-08", "2026-11-01", []string{"2026-10-08 09:00", "2026-10-09 09:00", "2026-10-10 09:00"}},
+		{"daily-byday", "2026-10-05", "2026-10-12", []string{"2026-10-05 09:00", "2026-10-07 09:00", "2026-10-09 09:00"}},
+		{"biweekly", "2026-10-01", "2026-12-01", []string{"2026-10-06 09:00", "2026-10-08 09:00", "2026-10-20 09:00", "2026-10-22 09:00"}},
+		{"standup", "2026-10-01", "2026-10-27", []string{"2026-10-05 09:00", "2026-10-20 10:00", "2026-10-26 09:00"}},
+		{"month-end", "2026-01-01", "2026-07-01", []string{"2026-01-31 09:00", "2026-03-31 09:00", "2026-05-31 09:00"}},
+		{"anniversary", "2026-01-01", "2027-01-01", []string{"2026-03-01 00:00"}},
+		// BY parts that are not supported give only the first occurrence
+		{"first-monday", "2026-10-01", "2027-01-01", []string{"2026-10-05 09:00"}},
+		{"last-workday", "2026-10-01", "2027-01-01", []string{"2026-10-30 15:00"}},
+		{"twice-a-year", "2026-01-01", "2028-01-01", []string{"2026-01-01 09:00"}},
+		// occurrences before the range do not count towards maxOccurrences
+		{"since-2000", "2026-10-19", "2026-10-21", []string{"2026-10-19 08:00", "2026-10-20 08:00"}},
+		{"cancelled", "2026-10-01", "2026-11-01", []string{}},
+	}
+
+	for _, tt := range tests {
+		from, _ := time.Parse(time.DateOnly, tt.from)
+		to, _ := time.Parse(time.DateOnly, tt.to)
+
+		events, err := Events(strings.NewReader(string(calendar)), from, to, time.UTC)
+		if err != nil {
+			t.Fatalf("Events(%s, %s): %v", tt.from, tt.to, err)
+		}
+
+		got := []string{}
+		for _, event := range events {
+			if uid, _, _ := strings.Cut(event.UID, "/"); uid == tt.uid {
+				got = append(got, event.Start.Format("2006-01-02 15:04"))
+			}
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s from %s to %s = %v, want %v", tt.uid, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestEventsNotCalendar(t *testing.T) {
+	_, err := Events(strings.NewReader("BEGIN:VCARD\r\nEND:VCARD\r\n"), time.Time{}, time.Now(), time.UTC)
+	if err != ErrNotCalendar {
+		t.Errorf("Events() error = %v, want %v", err, ErrNotCalendar)
+	}
+}
@@ -0,0 +1,150 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// columnParser parses CSV files with a header row, finding each field by
+// the names its column may have. Names are compared case-insensitively.
+type columnParser struct {
+	headers  map[string][]string
+	required []string
+	row      func(line int, get func(field string) string) Row
+}
+
+func (p columnParser) Parse(r io.Reader) ([]Row, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for field, headers := range p.headers {
+		for _, header := range headers {
+			names[header] = field
+		}
+	}
+
+	index := map[string]int{}
+	for i, name := range records.header {
+		field, ok := names[strings.ToLower(strings.TrimSpace(name))]
+		if _, seen := index[field]; ok && !seen {
+			index[field] = i
+		}
+	}
+
+	for _, field := range p.required {
+		if _, ok := index[field]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, p.headers[field][0])
+		}
+	}
+
+	rows := make([]Row, 0, len(records.rows))
+	for _, record := range records.rows {
+		get := func(field string) string {
+			return strings.TrimSpace(record.get(index, field))
+		}
+		rows = append(rows, p.row(record.line, get))
+	}
+
+	return rows, nil
+}
+
+type csvRecord struct {
+	line   int
+	fields []string
+}
+
+// get returns the named field, or an empty string when the column is
+// missing from the header or the record is short.
+func (r csvRecord) get(index map[string]int, column string) string {
+	i, ok := index[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return r.fields[i]
+}
+
+type csvFile struct {
+	header []string
+	rows   []csvRecord
+}
+
+// readCSV reads a CSV file with a header row, detecting the delimiter and
+// skipping a byte order mark and empty lines.
+func readCSV(r io.Reader) (*csvFile, error) {
+	buffered := bufio.NewReader(r)
+
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		buffered.Discard(3)
+	}
+
+	firstLine, err := buffered.Peek(buffered.Size())
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if i := strings.IndexByte(string(firstLine), '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(string(firstLine))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := csvFile{header: header}
+
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if isBlank(fields) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		file.rows = append(file.rows, csvRecord{line: line, fields: fields})
+	}
+
+	if len(file.rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	return &file, nil
+}
+
+func detectDelimiter(header string) rune {
+	delimiter, count := ';', strings.Count(header, ";")
+	for _, candidate := range []rune{',', '\t'} {
+		if n := strings.Count(header, string(candidate)); n > count {
+			delimiter, count = candidate, n
+		}
+	}
+	return delimiter
+}
+
+func isBlank(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
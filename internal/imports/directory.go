@@ -0,0 +1,114 @@
+package imports
+
+import (
+	"strings"
+
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/import_mappings"
+	"github.com/anvidev/project-time-tracker/internal/store/users"
+)
+
+// Directory looks up users and categories for rows. Users are found by
+// email and categories by their path, ignoring case and spaces around the
+// slashes. Retired categories are left out, so time is not imported to them. Once mappings are used, categories are only found through
+// mappings, and users through mappings before their email.
+type Directory struct {
+	users      map[string]int64
+	userNames  map[string][]int64
+	categories map[string][]int64
+	mapped     bool
+	mappings   map[string]import_mappings.Mapping
+}
+
+func NewDirectory(userList []users.User, paths []categories.Path) *Directory {
+	d := &Directory{
+		users:      make(map[string]int64, len(userList)),
+		userNames:  make(map[string][]int64, len(userList)),
+		categories: make(map[string][]int64, len(paths)),
+	}
+
+	for _, user := range userList {
+		d.users[NormalizeKey(user.Email)] = user.Id
+		d.userNames[NormalizeKey(user.Name)] = append(d.userNames[NormalizeKey(user.Name)], user.Id)
+	}
+
+	for _, path := range paths {
+		if path.IsRetired {
+			continue
+		}
+		key := NormalizeKey(path.Path)
+		d.categories[key] = append(d.categories[key], path.Id)
+	}
+
+	return d
+}
+
+// UseMappings makes the directory resolve rows of another tracker through
+// the confirmed mappings of its source.
+func (d *Directory) UseMappings(mappings []import_mappings.Mapping) {
+	d.mapped = true
+	d.mappings = make(map[string]import_mappings.Mapping, len(mappings))
+	for _, mapping := range mappings {
+		d.mappings[mapping.Kind+":"+NormalizeKey(mapping.ExternalKey)] = mapping
+	}
+}
+
+func (d *Directory) mapping(kind, key string) (import_mappings.Mapping, bool) {
+	mapping, ok := d.mappings[kind+":"+NormalizeKey(key)]
+	return mapping, ok
+}
+
+func (d *Directory) User(row Row) (int64, error) {
+	if mapping, ok := d.mapping(import_mappings.KindUser, userKey(row)); ok {
+		return *mapping.UserId, nil
+	}
+
+	if row.Email == "" {
+		return 0, ErrUnmappedUser
+	}
+
+	id, ok := d.users[NormalizeKey(row.Email)]
+	if !ok {
+		return 0, ErrUnknownUser
+	}
+	return id, nil
+}
+
+func (d *Directory) Category(path string) (int64, error) {
+	if d.mapped {
+		mapping, ok := d.mapping(import_mappings.KindCategory, path)
+		if !ok {
+			return 0, ErrUnmappedCategory
+		}
+		return *mapping.CategoryId, nil
+	}
+
+	ids := d.categories[NormalizeKey(path)]
+	switch len(ids) {
+	case 0:
+		return 0, ErrUnknownCategory
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, ErrAmbiguousPath
+	}
+}
+
+// NormalizeKey lowercases a path or name and trims the spaces around it and
+// its slashes, so keys compare like people read them.
+func NormalizeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, "/")
+}
+
+// userKey is the key of a user mapping, the email when the source has
+// emails and the name otherwise.
+func userKey(row Row) string {
+	if strings.TrimSpace(row.Email) != "" {
+		return row.Email
+	}
+	return row.User
+}
@@ -0,0 +1,262 @@
+// Package imports turns time entries from files exported by other systems
+// into registrations. A Parser reads a file into rows of text, a Directory
+// resolves users and category paths, and Run validates every row and hands
+// the resolved entries to the store, which commits them in one transaction.
+//
+// Files from other trackers name projects and users their own way. Propose
+// suggests how they map onto categories and users here, and once the
+// mappings are confirmed the Directory resolves rows through them.
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+var (
+	ErrMissingColumn    = errors.New("missing column")
+	ErrEmptyFile        = errors.New("the file has no rows")
+	ErrUnknownSource    = errors.New("unknown import format, use csv, toggl, clockify or harvest")
+	ErrUnknownUser      = errors.New("unknown user email")
+	ErrUnmappedUser     = errors.New("user is not mapped, confirm a mapping first")
+	ErrUnknownCategory  = errors.New("unknown category path")
+	ErrUnmappedCategory = errors.New("project is not mapped to a category, confirm a mapping first")
+	ErrAmbiguousPath    = errors.New("category path matches more than one category")
+	ErrInvalidBillable  = errors.New("invalid billable, use yes or no")
+	ErrInvalidDate      = errors.New("invalid date, use YYYY-MM-DD or DD-MM-YYYY")
+	ErrInvalidDuration  = errors.New("invalid duration, use e.g. 1h30m, 1:30 or 1,5")
+	ErrDurationRange    = errors.New("duration must be more than zero and at most 24 hours")
+	ErrRequired         = errors.New("required")
+)
+
+// maxDuration is the longest duration accepted for a single entry.
+const maxDuration = 24 * time.Hour
+
+// Row is a time entry as read from a file, before it is validated.
+type Row struct {
+	Line        int
+	Email       string
+	User        string // name of the user, used when a source has no emails
+	Category    string // category path, or client/project/task of another tracker
+	Date        string
+	Duration    string
+	Description string
+	Billable    string // empty uses the default of the category
+}
+
+// A Parser reads the rows of an import file. Problems with single rows are
+// left for Run to report, so a parser only fails when the file as a whole
+// cannot be read.
+type Parser interface {
+	Parse(r io.Reader) ([]Row, error)
+}
+
+// Importer stores resolved entries, see time_entries.Store.Import.
+type Importer interface {
+	Import(ctx context.Context, entries []time_entries.ImportTimeEntryInput, dryRun bool) ([]time_entries.ImportError, error)
+}
+
+type RowError struct {
+	Line  int    `json:"line"`
+	Field string `json:"field,omitempty"`
+	Error string `json:"error"`
+}
+
+type Result struct {
+	DryRun   bool       `json:"dryRun"`
+	Rows     int        `json:"rows"`
+	Valid    int        `json:"valid" apiduck:"desc=rows without errors"`
+	Imported int        `json:"imported" apiduck:"desc=0 unless the import was committed"`
+	Errors   []RowError `json:"errors"`
+}
+
+// Run validates rows, resolves them against directory and imports the valid
+// ones. Rows are only committed when dryRun is false and no row has errors,
+// otherwise the store checks the valid rows without committing them so all
+// errors are reported at once.
+func Run(ctx context.Context, importer Importer, directory *Directory, rows []Row, dryRun bool) (*Result, error) {
+	result := Result{
+		DryRun: dryRun,
+		Rows:   len(rows),
+		Errors: []RowError{},
+	}
+
+	entries := make([]time_entries.ImportTimeEntryInput, 0, len(rows))
+
+	for _, row := range rows {
+		entry, rowErrors := resolve(row, directory)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	commit := !dryRun && len(result.Errors) == 0
+
+	importErrors, err := importer.Import(ctx, entries, !commit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, importErr := range importErrors {
+		result.Errors = append(result.Errors, RowError{Line: importErr.Line, Error: importErr.Err.Error()})
+	}
+
+	slices.SortStableFunc(result.Errors, func(a, b RowError) int {
+		return a.Line - b.Line
+	})
+
+	result.Valid = len(entries) - len(importErrors)
+	if commit && len(importErrors) == 0 {
+		result.Imported = len(entries)
+	}
+
+	return &result, nil
+}
+
+func resolve(row Row, directory *Directory) (time_entries.ImportTimeEntryInput, []RowError) {
+	var (
+		entry     = time_entries.ImportTimeEntryInput{Line: row.Line}
+		rowErrors []RowError
+		err       error
+	)
+
+	fail := func(field string, err error) {
+		rowErrors = append(rowErrors, RowError{Line: row.Line, Field: field, Error: err.Error()})
+	}
+
+	if strings.TrimSpace(row.Email) == "" && strings.TrimSpace(row.User) == "" {
+		fail("email", ErrRequired)
+	} else if entry.UserId, err = directory.User(row); err != nil {
+		fail("email", err)
+	}
+
+	if strings.TrimSpace(row.Category) == "" {
+		fail("category", ErrRequired)
+	} else if entry.CategoryId, err = directory.Category(row.Category); err != nil {
+		fail("category", err)
+	}
+
+	if strings.TrimSpace(row.Date) == "" {
+		fail("date", ErrRequired)
+	} else if entry.Date, err = ParseDate(row.Date); err != nil {
+		fail("date", err)
+	}
+
+	if strings.TrimSpace(row.Duration) == "" {
+		fail("duration", ErrRequired)
+	} else if duration, err := ParseDuration(row.Duration); err != nil {
+		fail("duration", err)
+	} else {
+		entry.Duration = types.Duration{Duration: duration}
+	}
+
+	if entry.Billable, err = parseBillable(row.Billable); err != nil {
+		fail("billable", err)
+	}
+
+	entry.Description = strings.TrimSpace(row.Description)
+
+	return entry, rowErrors
+}
+
+var dateLayouts = []string{
+	time.DateOnly,
+	"02-01-2006",
+	"02.01.2006",
+	"02/01/2006",
+}
+
+// ParseDate reads a date as YYYY-MM-DD or a Danish day first date and
+// returns it as YYYY-MM-DD.
+func ParseDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.Format(time.DateOnly), nil
+		}
+	}
+	return "", ErrInvalidDate
+}
+
+// ParseDuration reads a duration written as a Go duration (1h30m), as hours
+// and minutes (1:30) or as decimal hours with a comma or a point (1,5).
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	var (
+		duration time.Duration
+		err      error
+	)
+
+	switch {
+	case strings.Contains(value, ":"):
+		duration, err = parseClock(value)
+	case strings.ContainsAny(value, "hms"):
+		duration, err = time.ParseDuration(value)
+	default:
+		var hours float64
+		hours, err = strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		duration = time.Duration(hours * float64(time.Hour)).Round(time.Second)
+	}
+
+	if err != nil {
+		return 0, ErrInvalidDuration
+	}
+
+	if duration <= 0 || duration > maxDuration {
+		return 0, ErrDurationRange
+	}
+
+	return duration, nil
+}
+
+// parseClock reads hh:mm or hh:mm:ss.
+func parseClock(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, ErrInvalidDuration
+	}
+
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+
+	var duration time.Duration
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidDuration, value)
+		}
+		duration += time.Duration(n) * units[i]
+	}
+
+	return duration, nil
+}
+
+// parseBillable reads yes or no in English or Danish. An empty value
+// returns nil, so the default of the category is used.
+func parseBillable(value string) (*bool, error) {
+	var billable bool
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return nil, nil
+	case "yes", "ja", "true", "1":
+		billable = true
+	case "no", "nej", "false", "0":
+		billable = false
+	default:
+		return nil, ErrInvalidBillable
+	}
+
+	return &billable, nil
+}
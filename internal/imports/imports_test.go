@@ -0,0 +1,99 @@
+package imports
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		err   error
+	}{
+		{"2026-10-19", "2026-10-19", nil},
+		{" 2026-10-19 ", "2026-10-19", nil},
+		{"19-10-2026", "2026-10-19", nil},
+		{"19.10.2026", "2026-10-19", nil},
+		{"19/10/2026", "2026-10-19", nil},
+		{"10/19/2026", "", ErrInvalidDate},
+		{"2026-02-30", "", ErrInvalidDate},
+		{"19.10.26", "", ErrInvalidDate},
+		{"", "", ErrInvalidDate},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDate(tt.value)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseDate(%q) = %q, %v, want %q, %v", tt.value, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   error
+	}{
+		{"1h30m", 90 * time.Minute, nil},
+		{"45m", 45 * time.Minute, nil},
+		{"1:30", 90 * time.Minute, nil},
+		{"0:15:30", 15*time.Minute + 30*time.Second, nil},
+		{"1,5", 90 * time.Minute, nil},
+		{"1.25", 75 * time.Minute, nil},
+		{" 7.5 ", 7*time.Hour + 30*time.Minute, nil},
+		{"0,1", 6 * time.Minute, nil},
+		{"1:60", 0, ErrInvalidDuration},
+		{"1:2:3:4", 0, ErrInvalidDuration},
+		{"abc", 0, ErrInvalidDuration},
+		{"", 0, ErrInvalidDuration},
+		{"0", 0, ErrDurationRange},
+		{"-1h", 0, ErrDurationRange},
+		{"25h", 0, ErrDurationRange},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v, %v", tt.value, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestDirectoryCategory(t *testing.T) {
+	directory := NewDirectory(nil, []categories.Path{
+		{Id: 1, Path: "Customer"},
+		{Id: 2, Path: "Customer/Meetings"},
+		{Id: 3, Path: "Customer/Support"},
+		{Id: 4, Path: "Customer/Support", IsRetired: true},
+		{Id: 5, Path: "Old customer", IsRetired: true},
+		{Id: 6, Path: "Old customer/Meetings", IsRetired: true},
+		{Id: 7, Path: "Internal/Admin"},
+		{Id: 8, Path: "internal / admin"},
+	})
+
+	tests := []struct {
+		path string
+		want int64
+		err  error
+	}{
+		{"Customer/Meetings", 2, nil},
+		{" customer / meetings ", 2, nil},
+		{"Customer/Support", 3, nil},
+		{"Old customer", 0, ErrUnknownCategory},
+		{"Old customer/Meetings", 0, ErrUnknownCategory},
+		{"Internal/Admin", 0, ErrAmbiguousPath},
+		{"Customer/Sales", 0, ErrUnknownCategory},
+	}
+
+	for _, tt := range tests {
+		got, err := directory.Category(tt.path)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Category(%q) = %d, %v, want %d, %v", tt.path, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package imports
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/anvidev/project-time-tracker/internal/store/import_mappings"
+)
+
+const (
+	StatusConfirmed string = "confirmed"
+	StatusMatched          = "matched"
+	StatusSuggested        = "suggested"
+	StatusUnmapped         = "unmapped"
+)
+
+// Proposal is a row of the mapping table shown before importing from
+// another tracker: a project or user of the file and what it maps to.
+type Proposal struct {
+	Kind        string `json:"kind" apiduck:"desc=category or user"`
+	ExternalKey string `json:"externalKey" apiduck:"desc=client/project/task for categories, email or name for users"`
+	Rows        int    `json:"rows" apiduck:"desc=rows in the file with the key"`
+	CategoryId  *int64 `json:"categoryId"`
+	UserId      *int64 `json:"userId"`
+	Status      string `json:"status" apiduck:"desc=confirmed (saved mapping), matched (user found by email), suggested or unmapped"`
+}
+
+// Propose lists the projects and users of rows with their confirmed
+// mapping, or a suggestion when none is confirmed. Categories are suggested
+// when the project path, or its end, matches a single category path, and
+// users when their email or name matches.
+func Propose(rows []Row, d *Directory) []Proposal {
+	proposals := map[string]*Proposal{}
+
+	count := func(kind, key string) *Proposal {
+		id := kind + ":" + NormalizeKey(key)
+		proposal, ok := proposals[id]
+		if !ok {
+			proposal = &Proposal{Kind: kind, ExternalKey: NormalizeKey(key), Status: StatusUnmapped}
+			proposals[id] = proposal
+		}
+		proposal.Rows++
+		return proposal
+	}
+
+	for _, row := range rows {
+		if row.Category != "" {
+			proposal := count(import_mappings.KindCategory, row.Category)
+			if proposal.Rows == 1 {
+				d.proposeCategory(proposal)
+			}
+		}
+
+		if key := userKey(row); strings.TrimSpace(key) != "" {
+			proposal := count(import_mappings.KindUser, key)
+			if proposal.Rows == 1 {
+				d.proposeUser(proposal, row)
+			}
+		}
+	}
+
+	list := make([]Proposal, 0, len(proposals))
+	for _, proposal := range proposals {
+		list = append(list, *proposal)
+	}
+
+	slices.SortFunc(list, func(a, b Proposal) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.ExternalKey, b.ExternalKey))
+	})
+
+	return list
+}
+
+func (d *Directory) proposeCategory(proposal *Proposal) {
+	if mapping, ok := d.mapping(import_mappings.KindCategory, proposal.ExternalKey); ok {
+		proposal.CategoryId = mapping.CategoryId
+		proposal.Status = StatusConfirmed
+		return
+	}
+
+	parts := strings.Split(proposal.ExternalKey, "/")
+	for i := range parts {
+		ids := d.categories[strings.Join(parts[i:], "/")]
+		if len(ids) == 1 {
+			proposal.CategoryId = &ids[0]
+			proposal.Status = StatusSuggested
+			return
+		}
+	}
+}
+
+func (d *Directory) proposeUser(proposal *Proposal, row Row) {
+	if mapping, ok := d.mapping(import_mappings.KindUser, proposal.ExternalKey); ok {
+		proposal.UserId = mapping.UserId
+		proposal.Status = StatusConfirmed
+		return
+	}
+
+	if id, ok := d.users[NormalizeKey(row.Email)]; ok && row.Email != "" {
+		proposal.UserId = &id
+		proposal.Status = StatusMatched
+		return
+	}
+
+	if ids := d.userNames[NormalizeKey(row.User)]; len(ids) == 1 && row.User != "" {
+		proposal.UserId = &ids[0]
+		proposal.Status = StatusSuggested
+	}
+}
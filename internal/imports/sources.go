@@ -0,0 +1,169 @@
+package imports
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	SourceCSV      string = "csv"
+	SourceToggl           = "toggl"
+	SourceClockify        = "clockify"
+	SourceHarvest         = "harvest"
+)
+
+// CSV is the format of this tracker, with the columns email, category,
+// date, duration, description and billable. Categories are paths from the
+// root, e.g. "Customer/Project/Meetings". The Danish column names are
+// accepted as well.
+var CSV Parser = columnParser{
+	headers: map[string][]string{
+		"email":       {"email", "e-mail"},
+		"category":    {"category", "kategori"},
+		"date":        {"date", "dato"},
+		"duration":    {"duration", "varighed"},
+		"description": {"description", "beskrivelse"},
+		"billable":    {"billable", "fakturerbar"},
+	},
+	required: []string{"email", "category", "date", "duration"},
+	row: func(line int, get func(string) string) Row {
+		return Row{
+			Line:        line,
+			Email:       get("email"),
+			Category:    get("category"),
+			Date:        get("date"),
+			Duration:    get("duration"),
+			Description: get("description"),
+			Billable:    get("billable"),
+		}
+	},
+}
+
+// Toggl is the detailed report CSV of Toggl Track.
+var Toggl Parser = columnParser{
+	headers: map[string][]string{
+		"user":        {"user", "member"},
+		"email":       {"email"},
+		"client":      {"client"},
+		"project":     {"project"},
+		"task":        {"task"},
+		"description": {"description"},
+		"billable":    {"billable"},
+		"date":        {"start date"},
+		"duration":    {"duration"},
+	},
+	required: []string{"email", "project", "date", "duration"},
+	row: func(line int, get func(string) string) Row {
+		return Row{
+			Line:        line,
+			Email:       get("email"),
+			User:        get("user"),
+			Category:    externalPath(get("client"), get("project"), get("task")),
+			Date:        get("date"),
+			Duration:    get("duration"),
+			Description: get("description"),
+			Billable:    get("billable"),
+		}
+	},
+}
+
+// Clockify is the detailed report CSV of Clockify. Its dates follow the
+// date format of the workspace, which is month first by default.
+var Clockify Parser = columnParser{
+	headers: map[string][]string{
+		"user":        {"user"},
+		"email":       {"email"},
+		"client":      {"client"},
+		"project":     {"project"},
+		"task":        {"task"},
+		"description": {"description"},
+		"billable":    {"billable"},
+		"date":        {"start date"},
+		"duration":    {"duration (h)", "duration (decimal)"},
+	},
+	required: []string{"email", "project", "date", "duration"},
+	row: func(line int, get func(string) string) Row {
+		return Row{
+			Line:        line,
+			Email:       get("email"),
+			User:        get("user"),
+			Category:    externalPath(get("client"), get("project"), get("task")),
+			Date:        reformatDate(get("date"), "01/02/2006"),
+			Duration:    get("duration"),
+			Description: get("description"),
+			Billable:    get("billable"),
+		}
+	},
+}
+
+// Harvest is the detailed time report CSV of Harvest. It has no emails, so
+// users are always mapped by name.
+var Harvest Parser = columnParser{
+	headers: map[string][]string{
+		"date":        {"date"},
+		"client":      {"client"},
+		"project":     {"project"},
+		"task":        {"task"},
+		"description": {"notes"},
+		"duration":    {"hours"},
+		"billable":    {"billable?"},
+		"firstName":   {"first name"},
+		"lastName":    {"last name"},
+	},
+	required: []string{"date", "project", "duration", "firstName"},
+	row: func(line int, get func(string) string) Row {
+		return Row{
+			Line:        line,
+			User:        strings.TrimSpace(get("firstName") + " " + get("lastName")),
+			Category:    externalPath(get("client"), get("project"), get("task")),
+			Date:        get("date"),
+			Duration:    get("duration"),
+			Description: get("description"),
+			Billable:    get("billable"),
+		}
+	},
+}
+
+var parsers = map[string]Parser{
+	SourceCSV:      CSV,
+	SourceToggl:    Toggl,
+	SourceClockify: Clockify,
+	SourceHarvest:  Harvest,
+}
+
+// ParserFor returns the parser of a source, see the Source constants.
+func ParserFor(source string) (Parser, error) {
+	parser, ok := parsers[source]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+	return parser, nil
+}
+
+// IsExternal reports whether a source is another tracker, whose projects
+// and users are resolved through mappings.
+func IsExternal(source string) bool {
+	_, ok := parsers[source]
+	return ok && source != SourceCSV
+}
+
+// externalPath joins the non-empty parts of a client/project/task triple.
+func externalPath(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, strings.ReplaceAll(part, "/", "-"))
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// reformatDate converts a date in layout to YYYY-MM-DD, leaving values in
+// other formats for ParseDate.
+func reformatDate(value, layout string) string {
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		return value
+	}
+	return date.Format(time.DateOnly)
+}
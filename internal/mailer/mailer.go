@@ -6,9 +6,19 @@ import "embed"
 var templates embed.FS
 
 var (
-	NotifyEmptyDay = "notify_empty_day.html"
+	NotifyEmptyDay    = "notify_empty_day.html"
+	BudgetAlert       = "budget_alert.html"
+	ReportSummary     = "report_summary.html"
+	ReportUtilization = "report_utilization.html"
 )
 
+// Attachment is a file sent along with a mail.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
 type Mailer interface {
-	Send(to []string, subject, tmpl string, data any) error
+	Send(to []string, subject, tmpl string, data any, attachments ...Attachment) error
 }
@@ -19,7 +19,7 @@ func NewResendMailer(apikey, from string) Mailer {
 	}
 }
 
-func (m resendMailer) Send(to []string, subject, tmpl string, data any) error {
+func (m resendMailer) Send(to []string, subject, tmpl string, data any, attachments ...Attachment) error {
 	html, err := template.ParseFS(templates, "templates/"+tmpl)
 	if err != nil {
 		return err
@@ -37,6 +37,14 @@ func (m resendMailer) Send(to []string, subject, tmpl string, data any) error {
 		Html:    body.String(),
 	}
 
+	for _, attachment := range attachments {
+		email.Attachments = append(email.Attachments, &resend.Attachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Content:     attachment.Content,
+		})
+	}
+
 	_, err = m.client.Emails.Send(email)
 
 	return err
@@ -0,0 +1,72 @@
+package pdf
+
+import (
+	"cmp"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+)
+
+// CategoryReport writes the time entries of a month grouped by category,
+// for customers who are billed per category. Only entries whose category is
+// in paths are included, with the path as the heading of each group. The
+// scope describes which categories the report covers.
+func CategoryReport(w io.Writer, userName string, year int, month time.Month, scope string, paths map[int64]string, summary *time_entries.SummaryMonth) error {
+	d := newDocument("Timerapport", [][2]string{
+		{"Medarbejder", userName},
+		{"Måned", monthTitle(year, month)},
+		{"Kategorier", scope},
+	}, []column{
+		{"Dato", 25, "L"},
+		{"Beskrivelse", 135, "L"},
+		{"Timer", 20, "R"},
+	})
+
+	groups := map[int64][]time_entries.TimeEntry{}
+	for _, day := range summary.Days {
+		for i := len(day.TimeEntries) - 1; i >= 0; i-- {
+			entry := day.TimeEntries[i]
+			if _, ok := paths[entry.CategoryId]; ok {
+				groups[entry.CategoryId] = append(groups[entry.CategoryId], entry)
+			}
+		}
+	}
+
+	ids := make([]int64, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b int64) int {
+		return cmp.Compare(paths[a], paths[b])
+	})
+
+	var total time.Duration
+
+	for _, id := range ids {
+		d.ensureSpace(3 * lineHeight)
+		d.pdf.Ln(2)
+		d.row([]string{paths[id], "", ""}, rowStyle{bold: true, shaded: true})
+
+		var subtotal time.Duration
+		for _, entry := range groups[id] {
+			d.row([]string{formatDate(entry.Date), entry.Description, hours(entry.Duration.Duration)}, rowStyle{border: "B"})
+			subtotal += entry.Duration.Duration
+		}
+
+		d.row([]string{"", "I alt", hours(subtotal)}, rowStyle{bold: true})
+		total += subtotal
+	}
+
+	if len(ids) == 0 {
+		d.row([]string{"", "Ingen registreringer i perioden", ""}, rowStyle{muted: true})
+	}
+
+	d.pdf.Ln(3)
+	d.row([]string{"", "Total", hours(total)}, rowStyle{bold: true, border: "T"})
+
+	d.signatures()
+
+	return d.pdf.Output(w)
+}
@@ -0,0 +1,181 @@
+// Package pdf renders monthly timesheets and category reports as PDF
+// documents for customers who require a signed account of the hours.
+// Texts are Danish and use the core Helvetica font, whose cp1252 encoding
+// covers the Danish letters, so no font files are embedded.
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	lineHeight = 5.0
+	margin     = 15.0
+)
+
+var monthNames = [...]string{
+	"januar", "februar", "marts", "april", "maj", "juni",
+	"juli", "august", "september", "oktober", "november", "december",
+}
+
+var weekdayNames = [...]string{"søn", "man", "tir", "ons", "tor", "fre", "lør"}
+
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+// document is an A4 page with a title, a few lines of details and a table
+// whose header is repeated on every page.
+type document struct {
+	pdf     *fpdf.Fpdf
+	tr      func(string) string
+	columns []column
+}
+
+func newDocument(title string, details [][2]string, columns []column) *document {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(false, margin)
+	pdf.AliasNbPages("")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Project Time Tracker", true)
+
+	d := &document{
+		pdf:     pdf,
+		tr:      pdf.UnicodeTranslatorFromDescriptor(""),
+		columns: columns,
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin + 5)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, lineHeight, d.tr(fmt.Sprintf("Side %d af {nb}", pdf.PageNo())), "", 0, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, d.tr(title), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, detail := range details {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(35, lineHeight+1, d.tr(detail[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, lineHeight+1, d.tr(detail[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	d.tableHeader()
+
+	return d
+}
+
+func (d *document) tableHeader() {
+	d.pdf.SetFont("Helvetica", "B", 9)
+	d.pdf.SetFillColor(230, 230, 230)
+	for _, column := range d.columns {
+		d.pdf.CellFormat(column.width, lineHeight+1, d.tr(column.title), "B", 0, column.align, true, 0, "")
+	}
+	d.pdf.Ln(-1)
+	d.pdf.SetFont("Helvetica", "", 9)
+}
+
+// ensureSpace starts a new page with the table header when height does not
+// fit on the current page.
+func (d *document) ensureSpace(height float64) {
+	_, pageHeight := d.pdf.GetPageSize()
+	if d.pdf.GetY()+height > pageHeight-margin {
+		d.pdf.AddPage()
+		d.tableHeader()
+	}
+}
+
+type rowStyle struct {
+	bold   bool
+	shaded bool
+	muted  bool
+	border string
+}
+
+// row writes a table row, wrapping values that are too wide for their
+// column onto more lines.
+func (d *document) row(values []string, style rowStyle) {
+	fontStyle := ""
+	if style.bold {
+		fontStyle = "B"
+	}
+	d.pdf.SetFont("Helvetica", fontStyle, 9)
+
+	if style.muted {
+		d.pdf.SetTextColor(110, 110, 110)
+		defer d.pdf.SetTextColor(0, 0, 0)
+	}
+
+	lines := make([][][]byte, len(values))
+	height := 1
+	for i, value := range values {
+		lines[i] = d.pdf.SplitLines([]byte(d.tr(value)), d.columns[i].width)
+		height = max(height, len(lines[i]))
+	}
+
+	d.ensureSpace(float64(height) * lineHeight)
+
+	d.pdf.SetFillColor(245, 245, 245)
+	x, y := d.pdf.GetX(), d.pdf.GetY()
+	for i, column := range d.columns {
+		d.pdf.SetXY(x, y)
+		d.pdf.CellFormat(column.width, float64(height)*lineHeight, "", style.border, 0, "", style.shaded, 0, "")
+		d.pdf.SetXY(x, y)
+		for _, line := range lines[i] {
+			d.pdf.CellFormat(column.width, lineHeight, string(line), "", 2, column.align, false, 0, "")
+		}
+		x += column.width
+	}
+	d.pdf.SetXY(margin, y+float64(height)*lineHeight)
+}
+
+// signatures writes lines for the employee and the customer or manager to
+// sign the document.
+func (d *document) signatures() {
+	d.ensureSpace(35)
+	d.pdf.Ln(20)
+
+	width := 80.0
+	y := d.pdf.GetY()
+	d.pdf.Line(margin, y, margin+width, y)
+	d.pdf.Line(210-margin-width, y, 210-margin, y)
+
+	d.pdf.SetFont("Helvetica", "", 9)
+	d.pdf.CellFormat(width, lineHeight, d.tr("Dato og underskrift, medarbejder"), "", 0, "L", false, 0, "")
+	d.pdf.SetX(210 - margin - width)
+	d.pdf.CellFormat(width, lineHeight, d.tr("Dato og underskrift, kunde eller leder"), "", 1, "L", false, 0, "")
+}
+
+func monthTitle(year int, month time.Month) string {
+	return fmt.Sprintf("%s %d", monthNames[month-1], year)
+}
+
+// hours formats a duration as decimal hours with a comma, e.g. 7,50.
+func hours(d time.Duration) string {
+	return strings.Replace(strconv.FormatFloat(d.Hours(), 'f', 2, 64), ".", ",", 1)
+}
+
+// formatDate formats YYYY-MM-DD as DD-MM-YYYY.
+func formatDate(value string) string {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return value
+	}
+	return date.Format("02-01-2006")
+}
@@ -0,0 +1,68 @@
+package pdf
+
+import (
+	"io"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+)
+
+// Timesheet writes the time entries of a month day by day with the expected
+// hours of each day, followed by totals and lines for signatures.
+func Timesheet(w io.Writer, userName string, year int, month time.Month, summary *time_entries.SummaryMonth) error {
+	d := newDocument("Timeseddel", [][2]string{
+		{"Medarbejder", userName},
+		{"Måned", monthTitle(year, month)},
+	}, []column{
+		{"Dato", 22, "L"},
+		{"Dag", 12, "L"},
+		{"Kategori", 45, "L"},
+		{"Beskrivelse", 67, "L"},
+		{"Timer", 17, "R"},
+		{"Norm", 17, "R"},
+	})
+
+	for _, day := range summary.Days {
+		date, _ := time.Parse(time.DateOnly, day.Date)
+		weekend := date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+		style := rowStyle{shaded: weekend || day.IsHoliday, border: "B"}
+
+		norm := ""
+		if day.MaxHours.Duration > 0 {
+			norm = hours(day.MaxHours.Duration)
+		}
+
+		first := []string{formatDate(day.Date), weekdayNames[date.Weekday()]}
+
+		if len(day.TimeEntries) == 0 {
+			note := ""
+			if day.IsHoliday {
+				note = day.HolidayName
+			}
+			d.row(append(first, note, "", "", norm), rowStyle{shaded: style.shaded, muted: true, border: "B"})
+			continue
+		}
+
+		for i := len(day.TimeEntries) - 1; i >= 0; i-- {
+			entry := day.TimeEntries[i]
+			values := []string{"", "", entry.Category, entry.Description, hours(entry.Duration.Duration), ""}
+			if i == len(day.TimeEntries)-1 {
+				copy(values, first)
+				values[5] = norm
+			}
+			d.row(values, style)
+		}
+
+		if len(day.TimeEntries) > 1 {
+			d.row([]string{"", "", "", "I alt", hours(day.TotalHours.Duration), ""}, rowStyle{bold: true, shaded: style.shaded, border: "B"})
+		}
+	}
+
+	d.pdf.Ln(3)
+	d.row([]string{"", "", "", "Registreret", hours(summary.TotalHours.Duration), hours(summary.MaxHours.Duration)}, rowStyle{bold: true})
+	d.row([]string{"", "", "", "Difference", hours(summary.TotalHours.Duration - summary.MaxHours.Duration), ""}, rowStyle{bold: true})
+
+	d.signatures()
+
+	return d.pdf.Output(w)
+}
@@ -0,0 +1,29 @@
+package absences
+
+import (
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+const (
+	KindVacation  string = "vacation"
+	KindSick             = "sick"
+	KindChildSick        = "child_sick"
+	KindLeave            = "leave"
+	KindOther            = "other"
+)
+
+type Absence struct {
+	Id          int64          `json:"id"`
+	UserId      int64          `json:"userId"`
+	Date        string         `json:"date"` // yyyy-MM-dd (time.DateOnly)
+	Kind        string         `json:"kind" apiduck:"desc=one of vacation, sick, child_sick, leave or other"`
+	Duration    types.Duration `json:"duration" apiduck:"desc=0s means the whole day"`
+	Description string         `json:"description"`
+}
+
+type RegisterAbsenceInput struct {
+	Date        string         `json:"date" validate:"required,datetime=2006-01-02"`
+	Kind        string         `json:"kind" validate:"required,oneof=vacation sick child_sick leave other"`
+	Duration    types.Duration `json:"duration"`
+	Description string         `json:"description"`
+}
@@ -0,0 +1,155 @@
+package absences
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+)
+
+var (
+	ErrAbsenceNotDeleted = errors.New("absence not deleted")
+	ErrAbsenceOverlaps   = errors.New("the day already has an overlapping absence")
+)
+
+// Register records an absence of the user. An absence overlaps another one
+// on the same day if either of them covers the whole day, or if they are of
+// the same kind, and is rejected so the day is not counted twice.
+func (s *Store) Register(ctx context.Context, userId int64, input RegisterAbsenceInput) (*Absence, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	overlapStmt := `
+		select exists (
+			select 1 from absences
+			where user_id = ?
+				and date = ?
+				and (? = 0 or duration = '0s' or kind = ?)
+		)
+	`
+
+	stmt := `
+		insert into absences (user_id, date, kind, duration, description)
+		values (?, ?, ?, ?, ?)
+		returning id
+	`
+
+	absence := Absence{
+		UserId:      userId,
+		Date:        input.Date,
+		Kind:        input.Kind,
+		Duration:    input.Duration,
+		Description: input.Description,
+	}
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Absence, error) {
+		var overlaps bool
+		if err := tx.QueryRowContext(
+			ctx,
+			overlapStmt,
+			absence.UserId,
+			absence.Date,
+			absence.Duration.Duration,
+			absence.Kind,
+		).Scan(&overlaps); err != nil {
+			return nil, err
+		}
+
+		if overlaps {
+			return nil, ErrAbsenceOverlaps
+		}
+
+		if err := tx.QueryRowContext(
+			ctx,
+			stmt,
+			absence.UserId,
+			absence.Date,
+			absence.Kind,
+			absence.Duration,
+			absence.Description,
+		).Scan(&absence.Id); err != nil {
+			return nil, err
+		}
+
+		return &absence, nil
+	})
+}
+
+func (s *Store) Delete(ctx context.Context, id, userId int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `delete from absences where id = ? and user_id = ?`
+
+	result, err := s.db.ExecContext(ctx, stmt, id, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrAbsenceNotDeleted
+	}
+
+	return nil
+}
+
+// List returns the absences of the user between from and to, both inclusive.
+// An empty kind matches every kind of absence.
+func (s *Store) List(ctx context.Context, userId int64, kind string, from, to time.Time) ([]Absence, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, user_id, date, kind, duration, description
+		from absences
+		where user_id = ?
+			and (? = '' or kind = ?)
+			and date >= ?
+			and date <= ?
+		order by date
+	`
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		stmt,
+		userId,
+		kind,
+		kind,
+		from.Format(time.DateOnly),
+		to.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	absences := []Absence{}
+
+	for rows.Next() {
+		var a Absence
+		if err := rows.Scan(
+			&a.Id,
+			&a.UserId,
+			&a.Date,
+			&a.Kind,
+			&a.Duration,
+			&a.Description,
+		); err != nil {
+			return nil, err
+		}
+		absences = append(absences, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return absences, nil
+}
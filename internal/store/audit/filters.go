@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultLimit int = 100
+	maxLimit         = 1000
+)
+
+var (
+	ErrInvalidUserId       = fmt.Errorf("invalid user id")
+	ErrInvalidEntityId     = fmt.Errorf("invalid entity id")
+	ErrInvalidFromDate     = fmt.Errorf("invalid from date")
+	ErrInvalidToDate       = fmt.Errorf("invalid to date")
+	ErrFromDateAfterToDate = fmt.Errorf("from date cannot be after to date")
+	ErrInvalidLimit        = fmt.Errorf("invalid limit")
+)
+
+type Filters struct {
+	UserId   *int64     `json:"userId"`
+	Entity   string     `json:"entity"`
+	EntityId *int64     `json:"entityId"`
+	FromDate *time.Time `json:"fromDate"`
+	ToDate   *time.Time `json:"toDate"`
+	Limit    int        `json:"limit"`
+}
+
+func (f *Filters) Parse(r *http.Request) error {
+	p := r.URL.Query()
+
+	f.Limit = defaultLimit
+
+	if p.Get("userId") != "" {
+		id, err := strconv.ParseInt(p.Get("userId"), 10, 64)
+		if err != nil {
+			return ErrInvalidUserId
+		}
+		f.UserId = &id
+	}
+
+	if p.Get("entity") != "" {
+		f.Entity = p.Get("entity")
+	}
+
+	if p.Get("entityId") != "" {
+		id, err := strconv.ParseInt(p.Get("entityId"), 10, 64)
+		if err != nil {
+			return ErrInvalidEntityId
+		}
+		f.EntityId = &id
+	}
+
+	if p.Get("fromDate") != "" {
+		parsed, err := time.Parse(time.DateOnly, p.Get("fromDate"))
+		if err != nil {
+			return ErrInvalidFromDate
+		}
+		f.FromDate = &parsed
+	}
+
+	if p.Get("toDate") != "" {
+		parsed, err := time.Parse(time.DateOnly, p.Get("toDate"))
+		if err != nil {
+			return ErrInvalidToDate
+		}
+		inclusive := parsed.Add(time.Hour*23 + time.Minute*59 + time.Second*59)
+		f.ToDate = &inclusive
+	}
+
+	if f.FromDate != nil && f.ToDate != nil && f.FromDate.After(*f.ToDate) {
+		return ErrFromDateAfterToDate
+	}
+
+	if p.Get("limit") != "" {
+		limit, err := strconv.Atoi(p.Get("limit"))
+		if err != nil || limit < 1 || limit > maxLimit {
+			return ErrInvalidLimit
+		}
+		f.Limit = limit
+	}
+
+	return nil
+}
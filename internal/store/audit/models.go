@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"encoding/json"
+)
+
+const (
+	ActionCreate  string = "create"
+	ActionUpdate         = "update"
+	ActionDelete         = "delete"
+	ActionRestore        = "restore"
+	ActionPurge          = "purge"
+)
+
+const (
+	EntityTimeEntry string = "time_entry"
+	EntityCategory         = "category"
+	EntityHours            = "hours"
+	EntityUser             = "user"
+	EntityClient           = "client"
+	EntityProject          = "project"
+)
+
+type Entry struct {
+	Id        int64           `json:"id"`
+	ActorId   *int64          `json:"actorId" apiduck:"desc=null when the change was not made by a logged in user"`
+	ActorName *string         `json:"actorName"`
+	Action    string          `json:"action" apiduck:"desc=one of create, update, delete, restore or purge"`
+	Entity    string          `json:"entity" apiduck:"desc=one of time_entry, category, hours, user, client or project"`
+	EntityId  int64           `json:"entityId"`
+	Before    json.RawMessage `json:"before" apiduck:"desc=the entity before the change, null on create"`
+	After     json.RawMessage `json:"after" apiduck:"desc=the entity after the change, null on delete"`
+	CreatedAt string          `json:"createdAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
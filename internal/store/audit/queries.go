@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/contextkeys"
+)
+
+// Record appends an entry to the audit log as part of tx, so the entry is only
+// kept if the change it describes is committed. The actor is the user id of
+// the authorized request in ctx, if any. before and after are stored as JSON
+// and may be nil.
+func Record(ctx context.Context, tx *sql.Tx, action, entity string, entityId int64, before, after any) error {
+	var actorId *int64
+	if userId, ok := ctx.Value(contextkeys.UserId).(int64); ok {
+		actorId = &userId
+	}
+
+	beforeJSON, err := marshal(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := marshal(after)
+	if err != nil {
+		return err
+	}
+
+	stmt := `
+		insert into audit_log (actor_id, action, entity, entity_id, before, after, created_at)
+		values (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		stmt,
+		actorId,
+		action,
+		entity,
+		entityId,
+		beforeJSON,
+		afterJSON,
+		time.Now().Format(time.DateTime),
+	)
+
+	return err
+}
+
+func marshal(v any) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	s := string(b)
+	return &s, nil
+}
+
+func (s *Store) List(ctx context.Context, filters Filters) ([]Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var fromDate, toDate *string
+	if filters.FromDate != nil {
+		formatted := filters.FromDate.Format(time.DateTime)
+		fromDate = &formatted
+	}
+	if filters.ToDate != nil {
+		formatted := filters.ToDate.Format(time.DateTime)
+		toDate = &formatted
+	}
+
+	stmt := `
+		select
+			a.id,
+			a.actor_id,
+			u.name,
+			a.action,
+			a.entity,
+			a.entity_id,
+			a.before,
+			a.after,
+			a.created_at
+		from audit_log a
+		left join users u on u.id = a.actor_id
+		where (? is null or a.actor_id = ?)
+			and (? = '' or a.entity = ?)
+			and (? is null or a.entity_id = ?)
+			and (? is null or a.created_at >= ?)
+			and (? is null or a.created_at <= ?)
+		order by a.id desc
+		limit ?
+	`
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		stmt,
+		filters.UserId,
+		filters.UserId,
+		filters.Entity,
+		filters.Entity,
+		filters.EntityId,
+		filters.EntityId,
+		fromDate,
+		fromDate,
+		toDate,
+		toDate,
+		filters.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+
+	for rows.Next() {
+		var (
+			e      Entry
+			before sql.NullString
+			after  sql.NullString
+		)
+
+		if err := rows.Scan(
+			&e.Id,
+			&e.ActorId,
+			&e.ActorName,
+			&e.Action,
+			&e.Entity,
+			&e.EntityId,
+			&before,
+			&after,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if before.Valid {
+			e.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			e.After = json.RawMessage(after.String)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,7 @@
+package calendar_feeds
+
+type Feed struct {
+	UserId    int64  `json:"userId"`
+	Token     string `json:"token" apiduck:"desc=secret part of the feed url, anyone with it can read the calendar"`
+	CreatedAt string `json:"createdAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
@@ -0,0 +1,100 @@
+package calendar_feeds
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/id"
+)
+
+var (
+	ErrFeedNotFound   = errors.New("calendar feed not found")
+	ErrFeedNotDeleted = errors.New("calendar feed not deleted")
+)
+
+func (s *Store) Get(ctx context.Context, userId int64) (*Feed, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `select user_id, token, created_at from calendar_feeds where user_id = ?`
+
+	var feed Feed
+	if err := s.db.QueryRowContext(ctx, stmt, userId).Scan(&feed.UserId, &feed.Token, &feed.CreatedAt); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrFeedNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &feed, nil
+}
+
+// Reset creates the feed of a user, or gives it a new token so the old url
+// stops working.
+func (s *Store) Reset(ctx context.Context, userId int64) (*Feed, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	feed := Feed{
+		UserId:    userId,
+		Token:     id.String(40, id.Numbers, id.LettersLower, id.LettersUpper),
+		CreatedAt: time.Now().Format(time.DateTime),
+	}
+
+	stmt := `
+		insert into calendar_feeds (user_id, token, created_at)
+		values (?, ?, ?)
+		on conflict (user_id) do update
+		set token = excluded.token,
+			created_at = excluded.created_at
+	`
+
+	if _, err := s.db.ExecContext(ctx, stmt, feed.UserId, feed.Token, feed.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+func (s *Store) Delete(ctx context.Context, userId int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `delete from calendar_feeds where user_id = ?`, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrFeedNotDeleted
+	}
+
+	return nil
+}
+
+// UserId returns the user a feed token belongs to.
+func (s *Store) UserId(ctx context.Context, token string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var userId int64
+	if err := s.db.QueryRowContext(ctx, `select user_id from calendar_feeds where token = ?`, token).Scan(&userId); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return 0, ErrFeedNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return userId, nil
+}
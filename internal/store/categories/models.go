@@ -1,25 +1,118 @@
 package categories
 
+import "github.com/anvidev/project-time-tracker/internal/types"
+
 type Category struct {
+	Id          int64   `json:"id"`
+	Title       string  `json:"title"`
+	RootTitle   string  `json:"rootTitle"`
+	ProjectId   *int64  `json:"projectId"`
+	IsBillable  bool    `json:"isBillable"`
+	Code        *string `json:"code" apiduck:"desc=external code, e.g. for accounting"`
+	Description string  `json:"description"`
+	Color       *string `json:"color" apiduck:"desc=hex color, e.g. #1f77b4"`
+	SortOrder   int     `json:"sortOrder"`
+}
+
+type Path struct {
 	Id        int64  `json:"id"`
-	Title     string `json:"title"`
-	RootTitle string `json:"rootTitle"`
+	Path      string `json:"path" apiduck:"desc=titles from the root separated by slashes"`
+	IsRetired bool   `json:"isRetired"`
 }
 
 type CategoryTree struct {
-	Id         int64           `json:"id"`
-	ParentId   *int64          `json:"parentId"`
-	Title      string          `json:"title"`
-	IsRetired  bool            `json:"isRetired"`
-	IsFollowed bool            `json:"isFollowed"`
-	Children   []*CategoryTree `json:"children"`
+	Id                int64           `json:"id"`
+	ParentId          *int64          `json:"parentId"`
+	Title             string          `json:"title"`
+	ProjectId         *int64          `json:"projectId"`
+	Code              *string         `json:"code"`
+	Description       string          `json:"description"`
+	Color             *string         `json:"color"`
+	SortOrder         int             `json:"sortOrder"`
+	Visibility        string          `json:"visibility" apiduck:"desc=everyone or restricted"`
+	IsRetired         bool            `json:"isRetired"`
+	IsBillable        bool            `json:"isBillable"`
+	IsFollowed        bool            `json:"isFollowed"`
+	IsDefaultFollowed bool            `json:"isDefaultFollowed" apiduck:"desc=followed automatically by new users"`
+	Budget            *types.Duration `json:"budget" apiduck:"desc=hour budget for the category and its subcategories, null when not set"`
+	Spent             types.Duration  `json:"spent" apiduck:"desc=time registered on the category and its subcategories"`
+	Remaining         *types.Duration `json:"remaining" apiduck:"desc=budget minus spent, negative when exceeded"`
+	Own               *types.Duration `json:"own,omitempty" apiduck:"desc=time registered directly on the category, only in tree totals"`
+	Total             *types.Duration `json:"total,omitempty" apiduck:"desc=time registered on the category and its subcategories, only in tree totals"`
+	Children          []*CategoryTree `json:"children"`
 }
 
 type CreateCategoryInput struct {
-	Title    string `json:"title"`
-	ParentId *int64 `json:"parentId"`
+	Title     string `json:"title"`
+	ParentId  *int64 `json:"parentId"`
+	ProjectId *int64 `json:"projectId" apiduck:"desc=only used for root categories, subcategories belong to the project of their parent"`
 }
 
 type UpdateCategoryInput struct {
-	Title string `json:"title"`
+	Title       string  `json:"title"`
+	Code        *string `json:"code" validate:"omitempty,max=50" apiduck:"desc=unchanged when omitted, empty removes the code"`
+	Description *string `json:"description" validate:"omitempty,max=1000" apiduck:"desc=unchanged when omitted"`
+	Color       *string `json:"color" validate:"omitempty,hexcolor|len=0" apiduck:"desc=unchanged when omitted, empty removes the color"`
+	SortOrder   *int    `json:"sortOrder" apiduck:"desc=unchanged when omitted"`
+}
+
+type SetBillableInput struct {
+	IsBillable bool `json:"isBillable"`
+}
+
+type SetDefaultFollowedInput struct {
+	IsDefaultFollowed bool `json:"isDefaultFollowed"`
+}
+
+type SetProjectInput struct {
+	ProjectId *int64 `json:"projectId"`
+}
+
+type SetBudgetInput struct {
+	Budget *types.Duration `json:"budget" apiduck:"desc=null removes the budget"`
+}
+
+// Budget is a category with an hour budget and the highest alert threshold,
+// in percent, that has been sent for it.
+type Budget struct {
+	Id      int64          `json:"id"`
+	Title   string         `json:"title"`
+	Budget  types.Duration `json:"budget"`
+	Alerted int            `json:"alerted"`
+}
+
+type MoveCategoryInput struct {
+	ParentId *int64 `json:"parentId" apiduck:"desc=null makes the category a root category"`
+}
+
+type MergeCategoryInput struct {
+	TargetId int64 `json:"targetId" validate:"required"`
+}
+
+const (
+	VisibilityEveryone   string = "everyone"
+	VisibilityRestricted        = "restricted"
+)
+
+// Access controls who can see, follow and register time on a category and
+// its subcategories. Restricted categories are visible to the listed users
+// and members of the listed teams.
+type Access struct {
+	Visibility string  `json:"visibility" apiduck:"desc=everyone or restricted"`
+	UserIds    []int64 `json:"userIds"`
+	TeamIds    []int64 `json:"teamIds"`
+}
+
+type SetAccessInput struct {
+	Visibility string  `json:"visibility" validate:"required,oneof=everyone restricted"`
+	UserIds    []int64 `json:"userIds"`
+	TeamIds    []int64 `json:"teamIds"`
+}
+
+// TotalsFilters limits the time entries counted in category totals. Nil
+// fields are not filtered on.
+type TotalsFilters struct {
+	From   *string // yyyy-MM-dd (time.DateOnly)
+	To     *string // yyyy-MM-dd (time.DateOnly)
+	UserId *int64
 }
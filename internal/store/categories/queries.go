@@ -5,6 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+	"github.com/anvidev/project-time-tracker/internal/store/locks"
+	"github.com/anvidev/project-time-tracker/internal/types"
 )
 
 var (
@@ -12,8 +18,42 @@ var (
 	ErrNotFollowingCategory = errors.New("category is not followed")
 	ErrCategoryNotFollowed  = errors.New("category was not followed")
 	ErrCategoryNotToggled   = errors.New("category was not toggled")
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrNotRootCategory      = errors.New("category is not a root category")
+	ErrInvalidBudget        = errors.New("budget must be positive")
+	ErrCategoryCycle        = errors.New("category cannot be moved below itself")
+	ErrCategoryNotEmpty     = errors.New("category has subcategories or time entries")
+	ErrMergeIntoSelf        = errors.New("category cannot be merged into itself")
+	ErrUnknownAccessId      = errors.New("unknown user or team id in access list")
 )
 
+// hiddenCategories is a recursive common table expression of the categories
+// a user is not allowed to see. A restricted category is hidden unless the
+// user is listed directly or through a team, and hiding a category hides its
+// subtree. Admins see every category. It takes the user id three times.
+const hiddenCategories = `
+	hidden(id) as (
+		select c.id
+		from categories c
+		where c.visibility = 'restricted'
+		  and coalesce((select role from users where id = ?), '') != 'admin'
+		  and not exists (
+			select 1 from category_access ca
+			where ca.category_id = c.id
+			  and (
+				ca.user_id = ?
+				or ca.team_id in (select team_id from team_members where user_id = ?)
+			  )
+		  )
+
+		union
+
+		select c.id
+		from categories c
+		join hidden h on c.parent_id = h.id
+	)`
+
 func (s *Store) Leafs(ctx context.Context, userId int64) ([]Category, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
@@ -21,7 +61,8 @@ func (s *Store) Leafs(ctx context.Context, userId int64) ([]Category, error) {
 	categories := []Category{}
 
 	stmt := `
-		with parent(id, title, parent_id, is_retired, path_parent_retired, root_parent_title) as (
+		with recursive ` + hiddenCategories + `,
+		parent(id, title, parent_id, is_retired, path_parent_retired, root_parent_title) as (
 		  select id, title, parent_id, is_retired, is_retired as path_parent_retired, title as root_parent_title
 		  from categories c
           join users_categories_link ucl on ucl.category_id = c.id
@@ -38,14 +79,16 @@ func (s *Store) Leafs(ctx context.Context, userId int64) ([]Category, error) {
 		  from categories
 		  where parent_id is not null
 		)
-		select distinct c.id, c.title, p.root_parent_title
+		select distinct c.id, c.title, p.root_parent_title, c.project_id, c.is_billable, c.code, c.description, c.color, c.sort_order
 		from categories c
 		join parent p on c.id = p.id
 		where c.id not in (select id from non_leafs)
-		  and p.path_parent_retired = 0;
+		  and c.id not in (select id from hidden)
+		  and p.path_parent_retired = 0
+		order by c.sort_order, c.title, c.id;
 	`
 
-	rows, err := s.db.QueryContext(ctx, stmt, userId)
+	rows, err := s.db.QueryContext(ctx, stmt, userId, userId, userId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +96,7 @@ func (s *Store) Leafs(ctx context.Context, userId int64) ([]Category, error) {
 
 	for rows.Next() {
 		var c Category
-		rows.Scan(&c.Id, &c.Title, &c.RootTitle)
+		rows.Scan(&c.Id, &c.Title, &c.RootTitle, &c.ProjectId, &c.IsBillable, &c.Code, &c.Description, &c.Color, &c.SortOrder)
 		categories = append(categories, c)
 	}
 
@@ -73,6 +116,10 @@ func (s *Store) Follow(ctx context.Context, id, userId int64) error {
 		values (?, ?)
 	`
 
+	if err := CheckVisible(ctx, s.db, userId, id); err != nil {
+		return err
+	}
+
 	result, err := s.db.ExecContext(ctx, stmt, id, userId)
 	if err != nil {
 		switch {
@@ -123,24 +170,35 @@ func (s *Store) Tree(ctx context.Context, userId int64) ([]*CategoryTree, error)
 	defer cancel()
 
 	stmt := `
+		with recursive ` + hiddenCategories + `
 		select 
 		  c.id,
 		  c.parent_id,
 		  c.title,
+		  c.project_id,
+		  c.code,
+		  c.description,
+		  c.color,
+		  c.sort_order,
 		  c.is_retired,
+		  c.is_billable,
+		  c.budget,
+		  c.visibility,
+		  c.is_default_followed,
 		  (select exists(select 1 from users_categories_link where user_id = ? and category_id = c.id)) as is_followed
 		from categories c
-		order by c.parent_id nulls first, c.id
+		where c.id not in (select id from hidden)
+		order by c.parent_id nulls first, c.sort_order, c.title, c.id
 	`
 
-	rows, err := s.db.QueryContext(ctx, stmt, userId)
+	rows, err := s.db.QueryContext(ctx, stmt, userId, userId, userId, userId)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	allCategories := make(map[int64]*CategoryTree)
-	var tree []*CategoryTree
+	ordered := []*CategoryTree{}
 
 	for rows.Next() {
 		var category CategoryTree
@@ -149,7 +207,16 @@ func (s *Store) Tree(ctx context.Context, userId int64) ([]*CategoryTree, error)
 			&category.Id,
 			&category.ParentId,
 			&category.Title,
+			&category.ProjectId,
+			&category.Code,
+			&category.Description,
+			&category.Color,
+			&category.SortOrder,
 			&category.IsRetired,
+			&category.IsBillable,
+			&category.Budget,
+			&category.Visibility,
+			&category.IsDefaultFollowed,
 			&category.IsFollowed,
 		); err != nil {
 			return nil, err
@@ -157,20 +224,21 @@ func (s *Store) Tree(ctx context.Context, userId int64) ([]*CategoryTree, error)
 
 		category.Children = make([]*CategoryTree, 0)
 		allCategories[category.Id] = &category
+		ordered = append(ordered, &category)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	for _, category := range allCategories {
-		if category.ParentId == nil {
-			tree = append(tree, category)
-		} else {
-			if parent, exists := allCategories[*category.ParentId]; exists {
-				parent.Children = append(parent.Children, category)
-			}
-		}
+	tree := linkTree(ordered, allCategories)
+
+	if err := s.addSpent(ctx, allCategories); err != nil {
+		return nil, err
+	}
+
+	for _, category := range tree {
+		rollupSpent(category)
 	}
 
 	return tree, nil
@@ -181,49 +249,106 @@ func (s *Store) Create(ctx context.Context, input CreateCategoryInput) (*Categor
 	defer cancel()
 
 	stmt := `
-		insert into categories (title, parent_id)
-		values (?, ?)
-		returning id, coalesce((select title from categories where id = ?), '') as root_title
+		insert into categories (title, parent_id, project_id)
+		values (?, ?, case when ? is null then ? else (select project_id from categories where id = ?) end)
+		returning id, coalesce((select title from categories where id = ?), '') as root_title, project_id, is_billable, code, description, color, sort_order
 	`
 
-	category := Category{Title: input.Title}
-	var rootTitle sql.NullString
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Category, error) {
+		if input.ParentId == nil && input.ProjectId != nil {
+			if err := checkProject(ctx, tx, *input.ProjectId); err != nil {
+				return nil, err
+			}
+		}
+
+		category := Category{Title: input.Title}
+		var rootTitle sql.NullString
 
-	err := s.db.
-		QueryRowContext(ctx, stmt, input.Title, input.ParentId, input.ParentId).
-		Scan(&category.Id, &rootTitle)
-	if err != nil {
-		return nil, err
-	}
+		err := tx.
+			QueryRowContext(
+				ctx,
+				stmt,
+				input.Title,
+				input.ParentId,
+				input.ParentId,
+				input.ProjectId,
+				input.ParentId,
+				input.ParentId,
+			).
+			Scan(&category.Id, &rootTitle, &category.ProjectId, &category.IsBillable, &category.Code, &category.Description, &category.Color, &category.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		category.RootTitle = rootTitle.String
+
+		after, err := s.getRow(ctx, tx, category.Id)
+		if err != nil {
+			return nil, err
+		}
 
-	category.RootTitle = rootTitle.String
+		if err := audit.Record(ctx, tx, audit.ActionCreate, audit.EntityCategory, category.Id, nil, after); err != nil {
+			return nil, err
+		}
 
-	return &category, nil
+		return &category, nil
+	})
 }
 
-func (s *Store) Update(ctx context.Context, id int64, title string) (*Category, error) {
+func (s *Store) Update(ctx context.Context, id int64, input UpdateCategoryInput) (*Category, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
 	stmt := `
 		update categories c
-		set title = ?
+		set
+			title = ?,
+			code = case when ? is null then code else nullif(?, '') end,
+			description = coalesce(?, description),
+			color = case when ? is null then color else nullif(?, '') end,
+			sort_order = coalesce(?, sort_order)
 		where id = ? 
-		returning id, coalesce((select title from categories where id = c.parent_id), '') as root_title
+		returning id, coalesce((select title from categories where id = c.parent_id), '') as root_title, project_id, is_billable, code, description, color, sort_order
 	`
 
-	category := Category{Title: title}
-	var rootTitle sql.NullString
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Category, error) {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
 
-	err := s.db.QueryRowContext(ctx, stmt, title, id).Scan(&category.Id, &rootTitle)
-	if err != nil {
-		return nil, err
-	}
+		category := Category{Title: input.Title}
+		var rootTitle sql.NullString
+
+		err = tx.QueryRowContext(
+			ctx,
+			stmt,
+			input.Title,
+			input.Code,
+			input.Code,
+			input.Description,
+			input.Color,
+			input.Color,
+			input.SortOrder,
+			id,
+		).Scan(&category.Id, &rootTitle, &category.ProjectId, &category.IsBillable, &category.Code, &category.Description, &category.Color, &category.SortOrder)
+		if err != nil {
+			return nil, err
+		}
 
-	category.RootTitle = rootTitle.String
+		category.RootTitle = rootTitle.String
 
-	return &category, nil
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after); err != nil {
+			return nil, err
+		}
 
+		return &category, nil
+	})
 }
 
 func (s *Store) ToggleRetire(ctx context.Context, id int64) error {
@@ -232,21 +357,87 @@ func (s *Store) ToggleRetire(ctx context.Context, id int64) error {
 
 	stmt := `update categories set is_retired = not is_retired where id = ?`
 
-	result, err := s.db.ExecContext(ctx, stmt, id)
-	if err != nil {
-		return err
-	}
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			switch err {
+			case ErrCategoryNotFound:
+				return ErrCategoryNotToggled
+			default:
+				return err
+			}
+		}
 
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+		result, err := tx.ExecContext(ctx, stmt, id)
+		if err != nil {
+			return err
+		}
 
-	if affected != 1 {
-		return ErrCategoryNotToggled
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected != 1 {
+			return ErrCategoryNotToggled
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+// categoryRow is the stored state of a category as recorded in the audit log.
+type categoryRow struct {
+	Id                int64           `json:"id"`
+	ParentId          *int64          `json:"parentId"`
+	Title             string          `json:"title"`
+	ProjectId         *int64          `json:"projectId"`
+	IsRetired         bool            `json:"isRetired"`
+	IsBillable        bool            `json:"isBillable"`
+	Budget            *types.Duration `json:"budget"`
+	Code              *string         `json:"code"`
+	Description       string          `json:"description"`
+	Color             *string         `json:"color"`
+	SortOrder         int             `json:"sortOrder"`
+	IsDefaultFollowed bool            `json:"isDefaultFollowed"`
+}
+
+func (s *Store) getRow(ctx context.Context, tx *sql.Tx, id int64) (*categoryRow, error) {
+	stmt := `
+		select id, parent_id, title, project_id, is_retired, is_billable, budget, code, description, color, sort_order, is_default_followed
+		from categories
+		where id = ?
+	`
+
+	var row categoryRow
+	if err := tx.QueryRowContext(ctx, stmt, id).Scan(
+		&row.Id,
+		&row.ParentId,
+		&row.Title,
+		&row.ProjectId,
+		&row.IsRetired,
+		&row.IsBillable,
+		&row.Budget,
+		&row.Code,
+		&row.Description,
+		&row.Color,
+		&row.SortOrder,
+		&row.IsDefaultFollowed,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrCategoryNotFound
+		default:
+			return nil, err
+		}
 	}
 
-	return nil
+	return &row, nil
 }
 
 func (s *Store) Get(ctx context.Context, id int64) (*Category, error) {
@@ -257,14 +448,20 @@ func (s *Store) Get(ctx context.Context, id int64) (*Category, error) {
 		select
 			c.id,
 			c.title,
-			coalesce((select title from categories where id = c.parent_id), '') as root_title
+			coalesce((select title from categories where id = c.parent_id), '') as root_title,
+			c.project_id,
+			c.is_billable,
+			c.code,
+			c.description,
+			c.color,
+			c.sort_order
 		from categories c
 		where c.id = ?
 	`
 
 	var c Category
 
-	if err := s.db.QueryRowContext(ctx, stmt, id, id).Scan(&c.Id, &c.Title, &c.RootTitle); err != nil {
+	if err := s.db.QueryRowContext(ctx, stmt, id, id).Scan(&c.Id, &c.Title, &c.RootTitle, &c.ProjectId, &c.IsBillable, &c.Code, &c.Description, &c.Color, &c.SortOrder); err != nil {
 		return nil, err
 	}
 
@@ -279,9 +476,15 @@ func (s *Store) List(ctx context.Context) ([]Category, error) {
 		select
 			c.id,
 			c.title,
-			coalesce((select title from categories where id = c.parent_id), '') as root_title
+			coalesce((select title from categories where id = c.parent_id), '') as root_title,
+			c.project_id,
+			c.is_billable,
+			c.code,
+			c.description,
+			c.color,
+			c.sort_order
 		from categories c
-		order by id
+		order by c.sort_order, c.title, c.id
 	`
 
 	rows, err := s.db.QueryContext(ctx, stmt)
@@ -294,7 +497,7 @@ func (s *Store) List(ctx context.Context) ([]Category, error) {
 
 	for rows.Next() {
 		var c Category
-		if err := rows.Scan(&c.Id, &c.Title, &c.RootTitle); err != nil {
+		if err := rows.Scan(&c.Id, &c.Title, &c.RootTitle, &c.ProjectId, &c.IsBillable, &c.Code, &c.Description, &c.Color, &c.SortOrder); err != nil {
 			return nil, err
 		}
 
@@ -303,3 +506,917 @@ func (s *Store) List(ctx context.Context) ([]Category, error) {
 
 	return categories, nil
 }
+
+// SetBillable sets whether time registered on the category is billable by default.
+func (s *Store) SetBillable(ctx context.Context, id int64, billable bool) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		stmt := `update categories set is_billable = ? where id = ?`
+
+		if _, err := tx.ExecContext(ctx, stmt, billable, id); err != nil {
+			return err
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+// SetProject moves a root category and all of its subcategories to a
+// project. A nil projectId detaches the tree from any project.
+func (s *Store) SetProject(ctx context.Context, id int64, projectId *int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		update categories
+		set project_id = ?
+		where id in (select id from subtree)
+	`
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if before.ParentId != nil {
+			return ErrNotRootCategory
+		}
+
+		if projectId != nil {
+			if err := checkProject(ctx, tx, *projectId); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, stmt, id, projectId); err != nil {
+			return err
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+func checkProject(ctx context.Context, tx *sql.Tx, projectId int64) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `select exists(select 1 from projects where id = ?)`, projectId).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// linkTree attaches every category to its parent and returns the roots.
+// ordered follows the sort order, so children keep it when appended.
+func linkTree(ordered []*CategoryTree, allCategories map[int64]*CategoryTree) []*CategoryTree {
+	var tree []*CategoryTree
+
+	for _, category := range ordered {
+		if category.ParentId == nil {
+			tree = append(tree, category)
+		} else {
+			if parent, exists := allCategories[*category.ParentId]; exists {
+				parent.Children = append(parent.Children, category)
+			}
+		}
+	}
+
+	return tree
+}
+
+// TreeTotals returns the whole category tree with the time registered
+// directly on each category and the cumulative time of its subtree.
+func (s *Store) TreeTotals(ctx context.Context, filters TotalsFilters) ([]*CategoryTree, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive ancestors(category_id, ancestor_id) as (
+		  select id, id from categories
+
+		  union all
+
+		  select a.category_id, c.parent_id
+		  from ancestors a
+		  join categories c on c.id = a.ancestor_id
+		  where c.parent_id is not null
+		),
+		own(category_id, seconds) as (
+		  select category_id, sum(duration_seconds)
+		  from time_entries
+		  where deleted_at is null
+		    and (? is null or date >= ?)
+		    and (? is null or date <= ?)
+		    and (? is null or user_id = ?)
+		  group by category_id
+		)
+		select
+		  c.id,
+		  c.parent_id,
+		  c.title,
+		  c.project_id,
+		  c.code,
+		  c.description,
+		  c.color,
+		  c.sort_order,
+		  c.is_retired,
+		  c.is_billable,
+		  c.budget,
+		  c.visibility,
+		  c.is_default_followed,
+		  coalesce((select seconds from own where category_id = c.id), 0) as own_seconds,
+		  coalesce((
+		    select sum(o.seconds)
+		    from ancestors a
+		    join own o on o.category_id = a.category_id
+		    where a.ancestor_id = c.id
+		  ), 0) as total_seconds
+		from categories c
+		order by c.parent_id nulls first, c.sort_order, c.title, c.id
+	`
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		stmt,
+		filters.From,
+		filters.From,
+		filters.To,
+		filters.To,
+		filters.UserId,
+		filters.UserId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allCategories := make(map[int64]*CategoryTree)
+	ordered := []*CategoryTree{}
+
+	for rows.Next() {
+		var (
+			category     CategoryTree
+			ownSeconds   int64
+			totalSeconds int64
+		)
+
+		if err := rows.Scan(
+			&category.Id,
+			&category.ParentId,
+			&category.Title,
+			&category.ProjectId,
+			&category.Code,
+			&category.Description,
+			&category.Color,
+			&category.SortOrder,
+			&category.IsRetired,
+			&category.IsBillable,
+			&category.Budget,
+			&category.Visibility,
+			&category.IsDefaultFollowed,
+			&ownSeconds,
+			&totalSeconds,
+		); err != nil {
+			return nil, err
+		}
+
+		category.Own = &types.Duration{Duration: time.Duration(ownSeconds) * time.Second}
+		category.Total = &types.Duration{Duration: time.Duration(totalSeconds) * time.Second}
+		category.Children = make([]*CategoryTree, 0)
+		allCategories[category.Id] = &category
+		ordered = append(ordered, &category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return linkTree(ordered, allCategories), nil
+}
+
+// addSpent sets the time registered directly on each category. The totals
+// are rolled up over subtrees by rollupSpent.
+func (s *Store) addSpent(ctx context.Context, allCategories map[int64]*CategoryTree) error {
+	stmt := `
+		select category_id, coalesce(sum(duration_seconds), 0)
+		from time_entries
+		where deleted_at is null
+		group by category_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			categoryId int64
+			seconds    int64
+		)
+		if err := rows.Scan(&categoryId, &seconds); err != nil {
+			return err
+		}
+		if category, exists := allCategories[categoryId]; exists {
+			category.Spent.Duration += time.Duration(seconds) * time.Second
+		}
+	}
+
+	return rows.Err()
+}
+
+func rollupSpent(category *CategoryTree) {
+	for _, child := range category.Children {
+		rollupSpent(child)
+		category.Spent.Duration += child.Spent.Duration
+	}
+
+	if category.Budget != nil {
+		category.Remaining = &types.Duration{Duration: category.Budget.Duration - category.Spent.Duration}
+	}
+}
+
+// SetBudget sets the hour budget of a category and its subcategories. Changing
+// the budget resets the alerts, so thresholds are reported again.
+func (s *Store) SetBudget(ctx context.Context, id int64, budget *types.Duration) error {
+	if budget != nil && budget.Duration <= 0 {
+		return ErrInvalidBudget
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `update categories set budget = ?, budget_alert = 0 where id = ?`
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, stmt, budget, id); err != nil {
+			return err
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+// Budgets returns every category that has an hour budget.
+func (s *Store) Budgets(ctx context.Context) ([]Budget, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, title, budget, budget_alert
+		from categories
+		where budget is not null
+		order by id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []Budget{}
+
+	for rows.Next() {
+		var b Budget
+		if err := rows.Scan(&b.Id, &b.Title, &b.Budget, &b.Alerted); err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+// SetBudgetAlert records the highest alert threshold, in percent, that has
+// been sent for the budget of a category.
+func (s *Store) SetBudgetAlert(ctx context.Context, id int64, threshold int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `update categories set budget_alert = ? where id = ?`
+
+	_, err := s.db.ExecContext(ctx, stmt, threshold, id)
+	return err
+}
+
+// isInSubtree reports whether candidate is id or one of its descendants.
+func isInSubtree(ctx context.Context, tx *sql.Tx, id, candidate int64) (bool, error) {
+	stmt := `
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		select exists(select 1 from subtree where id = ?)
+	`
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, stmt, id, candidate).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// Move places a category and its subcategories below a new parent, or makes
+// it a root category when parentId is nil. Moved categories take the project
+// of their new parent.
+func (s *Store) Move(ctx context.Context, id int64, parentId *int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		update categories
+		set
+			parent_id = case when id = ? then ? else parent_id end,
+			project_id = case when ? is null then project_id else (select project_id from categories where id = ?) end
+		where id in (select id from subtree)
+	`
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if parentId != nil {
+			if _, err := s.getRow(ctx, tx, *parentId); err != nil {
+				return err
+			}
+
+			cycle, err := isInSubtree(ctx, tx, id, *parentId)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrCategoryCycle
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, stmt, id, id, parentId, parentId, parentId); err != nil {
+			return err
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+// timeEntryRow is the part of a time entry recorded in the audit log when a
+// merge moves it to another category.
+type timeEntryRow struct {
+	Id          int64  `json:"id"`
+	CategoryId  int64  `json:"categoryId"`
+	UserId      int64  `json:"userId"`
+	Date        string `json:"date"`
+	Duration    string `json:"duration"`
+	Description string `json:"description"`
+	Billable    bool   `json:"billable"`
+}
+
+// Merge moves the time entries, followers and subcategories of a category to
+// target and deletes the category afterwards. The merge is rejected if any of
+// the time entries are in a submitted timesheet or a locked period.
+func (s *Store) Merge(ctx context.Context, id, targetId int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if id == targetId {
+		return ErrMergeIntoSelf
+	}
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.getRow(ctx, tx, targetId); err != nil {
+			return err
+		}
+
+		cycle, err := isInSubtree(ctx, tx, id, targetId)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrCategoryCycle
+		}
+
+		rows, err := tx.QueryContext(ctx, `
+			select id, category_id, user_id, date, duration, description, billable
+			from time_entries
+			where category_id = ?
+		`, id)
+		if err != nil {
+			return err
+		}
+
+		entries := []timeEntryRow{}
+		for rows.Next() {
+			var e timeEntryRow
+			if err := rows.Scan(&e.Id, &e.CategoryId, &e.UserId, &e.Date, &e.Duration, &e.Description, &e.Billable); err != nil {
+				rows.Close()
+				return err
+			}
+			entries = append(entries, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if err := locks.CheckEditable(ctx, tx, e.UserId, e.Date); err != nil {
+				return err
+			}
+		}
+
+		// the subcategories move below target, so like Move they take the
+		// project of their new parent
+		projectStmt := `
+			with recursive subtree(id) as (
+				select id from categories where parent_id = ?
+				union all
+				select c.id from categories c
+				join subtree st on c.parent_id = st.id
+			)
+			update categories
+			set project_id = (select project_id from categories where id = ?)
+			where id in (select id from subtree)
+		`
+		if _, err := tx.ExecContext(ctx, projectStmt, id, targetId); err != nil {
+			return err
+		}
+
+		stmts := []string{
+			`update time_entries set category_id = ? where category_id = ?`,
+			`update categories set parent_id = ? where parent_id = ?`,
+			`insert or ignore into users_categories_link (user_id, category_id)
+			 select user_id, ? from users_categories_link where category_id = ?`,
+			`update or ignore hourly_rates set category_id = ? where category_id = ?`,
+			`update import_mappings set category_id = ? where category_id = ?`,
+			`update suggestion_rules set category_id = ? where category_id = ?`,
+		}
+		for _, stmt := range stmts {
+			if _, err := tx.ExecContext(ctx, stmt, targetId, id); err != nil {
+				return err
+			}
+		}
+
+		if err := deleteCategory(ctx, tx, id); err != nil {
+			return err
+		}
+
+		for _, before := range entries {
+			after := before
+			after.CategoryId = targetId
+			if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityTimeEntry, before.Id, before, after); err != nil {
+				return err
+			}
+		}
+
+		return audit.Record(ctx, tx, audit.ActionDelete, audit.EntityCategory, id, before, nil)
+	})
+}
+
+// Delete removes a category without subcategories or time entries, including
+// time entries in the trash.
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select
+			exists(select 1 from categories where parent_id = ?)
+			or exists(select 1 from time_entries where category_id = ?)
+	`
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var inUse bool
+		if err := tx.QueryRowContext(ctx, stmt, id, id).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return ErrCategoryNotEmpty
+		}
+
+		if err := deleteCategory(ctx, tx, id); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionDelete, audit.EntityCategory, id, before, nil)
+	})
+}
+
+// deleteCategory removes a category and the rows referring to it.
+func deleteCategory(ctx context.Context, tx *sql.Tx, id int64) error {
+	stmts := []string{
+		`delete from users_categories_link where category_id = ?`,
+		`delete from hourly_rates where category_id = ?`,
+		`delete from category_access where category_id = ?`,
+		`delete from import_mappings where category_id = ?`,
+		`delete from suggestion_rules where category_id = ?`,
+		`delete from categories where id = ?`,
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// CheckVisible returns ErrCategoryNotFound when the category does not exist
+// or is hidden from the user by its access rules or those of its ancestors.
+func CheckVisible(ctx context.Context, q querier, userId, id int64) error {
+	stmt := `
+		with recursive ` + hiddenCategories + `
+		select exists(
+			select 1 from categories
+			where id = ? and id not in (select id from hidden)
+		)
+	`
+
+	var visible bool
+	if err := q.QueryRowContext(ctx, stmt, userId, userId, userId, id).Scan(&visible); err != nil {
+		return err
+	}
+
+	if !visible {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) Access(ctx context.Context, id int64) (*Access, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Access, error) {
+		return getAccess(ctx, tx, id)
+	})
+}
+
+func getAccess(ctx context.Context, tx *sql.Tx, id int64) (*Access, error) {
+	access := Access{
+		UserIds: []int64{},
+		TeamIds: []int64{},
+	}
+
+	if err := tx.QueryRowContext(ctx, `select visibility from categories where id = ?`, id).Scan(&access.Visibility); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrCategoryNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		select user_id, team_id
+		from category_access
+		where category_id = ?
+		order by user_id, team_id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userId, teamId *int64
+		if err := rows.Scan(&userId, &teamId); err != nil {
+			return nil, err
+		}
+		if userId != nil {
+			access.UserIds = append(access.UserIds, *userId)
+		}
+		if teamId != nil {
+			access.TeamIds = append(access.TeamIds, *teamId)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
+
+// SetAccess replaces the visibility and access list of a category. The rules
+// also apply to all subcategories.
+func (s *Store) SetAccess(ctx context.Context, id int64, input SetAccessInput) (*Access, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Access, error) {
+		before, err := getAccess(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, `update categories set visibility = ? where id = ?`, input.Visibility, id); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, `delete from category_access where category_id = ?`, id); err != nil {
+			return nil, err
+		}
+
+		grants := []struct {
+			stmt string
+			ids  []int64
+		}{
+			{`insert or ignore into category_access (category_id, user_id) select ?, id from users where id = ?`, input.UserIds},
+			{`insert or ignore into category_access (category_id, team_id) select ?, id from teams where id = ?`, input.TeamIds},
+		}
+
+		for _, grant := range grants {
+			for _, grantId := range grant.ids {
+				if _, err := tx.ExecContext(ctx, grant.stmt, id, grantId); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		after, err := getAccess(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(after.UserIds) != countUnique(input.UserIds) || len(after.TeamIds) != countUnique(input.TeamIds) {
+			return nil, ErrUnknownAccessId
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after); err != nil {
+			return nil, err
+		}
+
+		return after, nil
+	})
+}
+
+func countUnique(ids []int64) int {
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+	return len(seen)
+}
+
+// SetDefaultFollowed sets whether new users follow the category from the start.
+func (s *Store) SetDefaultFollowed(ctx context.Context, id int64, followed bool) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		stmt := `update categories set is_default_followed = ? where id = ?`
+
+		if _, err := tx.ExecContext(ctx, stmt, followed, id); err != nil {
+			return err
+		}
+
+		after, err := s.getRow(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityCategory, id, before, after)
+	})
+}
+
+// FollowDefaults makes a user follow every open category marked as default
+// followed that is visible to the user. It is used when registering users.
+func FollowDefaults(ctx context.Context, tx *sql.Tx, userId int64) error {
+	stmt := `
+		with recursive ` + hiddenCategories + `
+		insert or ignore into users_categories_link (user_id, category_id)
+		select ?, id
+		from categories
+		where is_default_followed = 1
+		  and is_retired = 0
+		  and id not in (select id from hidden)
+	`
+
+	_, err := tx.ExecContext(ctx, stmt, userId, userId, userId, userId)
+	return err
+}
+
+// FollowSubtree follows a category and all of its open subcategories that are
+// visible to the user. It returns the number of newly followed categories.
+func (s *Store) FollowSubtree(ctx context.Context, id, userId int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive ` + hiddenCategories + `,
+		subtree(id) as (
+			select id from categories where id = ? and is_retired = 0
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+			where c.is_retired = 0
+		)
+		insert or ignore into users_categories_link (user_id, category_id)
+		select ?, id
+		from subtree
+		where id not in (select id from hidden)
+	`
+
+	if err := CheckVisible(ctx, s.db, userId, id); err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.ExecContext(ctx, stmt, userId, userId, userId, id, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// UnfollowSubtree unfollows a category and all of its subcategories. It
+// returns the number of unfollowed categories.
+func (s *Store) UnfollowSubtree(ctx context.Context, id, userId int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		delete from users_categories_link
+		where user_id = ? and category_id in (select id from subtree)
+	`
+
+	result, err := s.db.ExecContext(ctx, stmt, id, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Paths lists every category with its full path of titles from the root,
+// separated by slashes, e.g. "Customer/Project/Meetings". A category counts
+// as retired when it or one of its ancestors is retired.
+func (s *Store) Paths(ctx context.Context) ([]Path, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive paths(id, path, is_retired) as (
+			select id, title, is_retired
+			from categories
+			where parent_id is null
+
+			union all
+
+			select c.id, p.path || '/' || c.title, p.is_retired or c.is_retired
+			from categories c
+			join paths p on c.parent_id = p.id
+		)
+		select id, path, is_retired
+		from paths
+		order by path, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paths := []Path{}
+
+	for rows.Next() {
+		var p Path
+		if err := rows.Scan(&p.Id, &p.Path, &p.IsRetired); err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// Subtree returns the id of a category and of all categories below it,
+// retired or not.
+func (s *Store) Subtree(ctx context.Context, id int64) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		select id from subtree
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+
+	return ids, nil
+}
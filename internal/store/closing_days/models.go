@@ -0,0 +1,30 @@
+package closing_days
+
+import (
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+// ClosingDay is a day the company is closed, or closes early, on top of the
+// public holidays.
+type ClosingDay struct {
+	Id    int64           `json:"id"`
+	Date  string          `json:"date"` // yyyy-MM-dd (time.DateOnly)
+	Name  string          `json:"name"`
+	Hours *types.Duration `json:"hours" apiduck:"desc=reduced working hours on the day, null when closed all day"`
+}
+
+func (c ClosingDay) Holiday() holidays.Holiday {
+	return holidays.Holiday{
+		Date:  c.Date,
+		Name:  c.Name,
+		Kind:  holidays.KindClosing,
+		Hours: c.Hours,
+	}
+}
+
+type CreateClosingDayInput struct {
+	Date  string          `json:"date" validate:"required,datetime=2006-01-02"`
+	Name  string          `json:"name" validate:"required,max=100"`
+	Hours *types.Duration `json:"hours"`
+}
@@ -0,0 +1,99 @@
+package closing_days
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDuplicateDate        = errors.New("closing day already exists for date")
+	ErrClosingDayNotDeleted = errors.New("closing day not deleted")
+)
+
+func (s *Store) Create(ctx context.Context, input CreateClosingDayInput) (*ClosingDay, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into closing_days (date, name, hours)
+		values (?, ?, ?)
+		returning id
+	`
+
+	day := ClosingDay{
+		Date:  input.Date,
+		Name:  input.Name,
+		Hours: input.Hours,
+	}
+
+	if err := s.db.QueryRowContext(ctx, stmt, day.Date, day.Name, day.Hours).Scan(&day.Id); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+			return nil, ErrDuplicateDate
+		default:
+			return nil, err
+		}
+	}
+
+	return &day, nil
+}
+
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `delete from closing_days where id = ?`
+
+	result, err := s.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrClosingDayNotDeleted
+	}
+
+	return nil
+}
+
+// List returns the closing days between from and to, both inclusive.
+func (s *Store) List(ctx context.Context, from, to time.Time) ([]ClosingDay, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, date, name, hours
+		from closing_days
+		where date >= ? and date <= ?
+		order by date
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []ClosingDay{}
+
+	for rows.Next() {
+		var d ClosingDay
+		if err := rows.Scan(&d.Id, &d.Date, &d.Name, &d.Hours); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
 )
 
 func (s *Store) AllWeekdays(ctx context.Context, userId int64) ([]Weekday, error) {
@@ -42,6 +43,11 @@ func (s *Store) UpdateWeekdays(ctx context.Context, userId int64, data []Weekday
 	defer cancel()
 
 	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.weekdays(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+
 		stmt := `
 		update users_hours set hours = ? where user_id = ? and weekday = ?
 		`
@@ -52,6 +58,36 @@ func (s *Store) UpdateWeekdays(ctx context.Context, userId int64, data []Weekday
 			}
 		}
 
-		return nil
+		after, err := s.weekdays(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityHours, userId, before, after)
 	})
 }
+
+func (s *Store) weekdays(ctx context.Context, tx *sql.Tx, userId int64) ([]Weekday, error) {
+	stmt := `select weekday, hours from users_hours where user_id = ? order by weekday`
+
+	rows, err := tx.QueryContext(ctx, stmt, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []Weekday{}
+	for rows.Next() {
+		var day Weekday
+		if err := rows.Scan(&day.Weekday, &day.Hours); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
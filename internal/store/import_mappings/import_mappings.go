@@ -0,0 +1,18 @@
+package import_mappings
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Store struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		db:           db,
+		queryTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,29 @@
+package import_mappings
+
+const (
+	KindCategory string = "category"
+	KindUser            = "user"
+)
+
+// Mapping links a key from another tracker's export, such as a
+// "client/project/task" path or a user name, to a category or user here.
+type Mapping struct {
+	Id          int64  `json:"id"`
+	Source      string `json:"source" apiduck:"desc=toggl, clockify or harvest"`
+	Kind        string `json:"kind" apiduck:"desc=category or user"`
+	ExternalKey string `json:"externalKey"`
+	CategoryId  *int64 `json:"categoryId"`
+	UserId      *int64 `json:"userId"`
+	UpdatedAt   string `json:"updatedAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
+
+type SaveMappingInput struct {
+	Kind        string `json:"kind" validate:"oneof=category user"`
+	ExternalKey string `json:"externalKey" validate:"required,max=500"`
+	CategoryId  *int64 `json:"categoryId" validate:"required_if=Kind category,excluded_unless=Kind category"`
+	UserId      *int64 `json:"userId" validate:"required_if=Kind user,excluded_unless=Kind user"`
+}
+
+type SaveMappingsInput struct {
+	Mappings []SaveMappingInput `json:"mappings" validate:"required,dive"`
+}
@@ -0,0 +1,133 @@
+package import_mappings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+)
+
+var (
+	ErrMappingNotDeleted = errors.New("import mapping not deleted")
+	ErrUnknownCategoryId = errors.New("unknown category id in mappings")
+	ErrUnknownUserId     = errors.New("unknown user id in mappings")
+)
+
+func (s *Store) List(ctx context.Context, source string) ([]Mapping, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, source, kind, external_key, category_id, user_id, updated_at
+		from import_mappings
+		where source = ?
+		order by kind, external_key
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappings := []Mapping{}
+
+	for rows.Next() {
+		var m Mapping
+		if err := rows.Scan(&m.Id, &m.Source, &m.Kind, &m.ExternalKey, &m.CategoryId, &m.UserId, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		mappings = append(mappings, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// Save confirms mappings for a source, replacing earlier mappings of the
+// same keys.
+func (s *Store) Save(ctx context.Context, source string, input []SaveMappingInput) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		stmt := `
+			insert into import_mappings (source, kind, external_key, category_id, user_id, updated_at)
+			values (?, ?, ?, ?, ?, ?)
+			on conflict (source, kind, external_key) do update
+			set category_id = excluded.category_id,
+				user_id = excluded.user_id,
+				updated_at = excluded.updated_at
+		`
+
+		now := time.Now().Format(time.DateTime)
+
+		for _, mapping := range input {
+			if err := checkTarget(ctx, tx, mapping); err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(
+				ctx,
+				stmt,
+				source,
+				mapping.Kind,
+				mapping.ExternalKey,
+				mapping.CategoryId,
+				mapping.UserId,
+				now,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkTarget verifies that the category or user of a mapping exists, as
+// foreign keys are not enforced.
+func checkTarget(ctx context.Context, tx *sql.Tx, mapping SaveMappingInput) error {
+	stmt, id, unknown := `select exists(select 1 from users where id = ?)`, mapping.UserId, ErrUnknownUserId
+	if mapping.Kind == KindCategory {
+		stmt, id, unknown = `select exists(select 1 from categories where id = ?)`, mapping.CategoryId, ErrUnknownCategoryId
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, stmt, id).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return unknown
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, source string, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `delete from import_mappings where source = ? and id = ?`, source, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrMappingNotDeleted
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package locks
+
+// Lock freezes time entries in a date range, either for every user or for a
+// single user. A lock stays in the table after it is unlocked so there is a
+// record of who locked and unlocked the period.
+type Lock struct {
+	Id         int64   `json:"id"`
+	UserId     *int64  `json:"userId" apiduck:"desc=null when the lock applies to all users"`
+	FromDate   string  `json:"fromDate"` // yyyy-MM-dd (time.DateOnly)
+	ToDate     string  `json:"toDate"`   // yyyy-MM-dd (time.DateOnly)
+	Reason     string  `json:"reason"`
+	LockedBy   int64   `json:"lockedBy"`
+	LockedAt   string  `json:"lockedAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+	UnlockedBy *int64  `json:"unlockedBy"`
+	UnlockedAt *string `json:"unlockedAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
+
+type CreateLockInput struct {
+	UserId   *int64 `json:"userId"`
+	FromDate string `json:"fromDate" validate:"required,datetime=2006-01-02"`
+	ToDate   string `json:"toDate" validate:"required,datetime=2006-01-02"`
+	Reason   string `json:"reason" validate:"max=500"`
+}
@@ -0,0 +1,194 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrLockNotFound    = errors.New("lock not found")
+	ErrAlreadyUnlocked = errors.New("lock is already unlocked")
+	ErrInvalidRange    = errors.New("to date cannot be before from date")
+
+	ErrTimesheetSubmitted = errors.New("time entries in a submitted or approved timesheet cannot be changed")
+	ErrPeriodLocked       = errors.New("time entries in a locked period cannot be changed")
+)
+
+func (s *Store) Create(ctx context.Context, lockedBy int64, input CreateLockInput) (*Lock, error) {
+	if input.ToDate < input.FromDate {
+		return nil, ErrInvalidRange
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into period_locks (user_id, from_date, to_date, reason, locked_by, locked_at)
+		values (?, ?, ?, ?, ?, ?)
+		returning id
+	`
+
+	lock := Lock{
+		UserId:   input.UserId,
+		FromDate: input.FromDate,
+		ToDate:   input.ToDate,
+		Reason:   input.Reason,
+		LockedBy: lockedBy,
+		LockedAt: time.Now().Format(time.DateTime),
+	}
+
+	if err := s.db.QueryRowContext(
+		ctx,
+		stmt,
+		lock.UserId,
+		lock.FromDate,
+		lock.ToDate,
+		lock.Reason,
+		lock.LockedBy,
+		lock.LockedAt,
+	).Scan(&lock.Id); err != nil {
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+func (s *Store) Unlock(ctx context.Context, unlockedBy, id int64) (*Lock, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update period_locks
+		set unlocked_by = ?, unlocked_at = ?
+		where id = ? and unlocked_at is null
+		returning id, user_id, from_date, to_date, reason, locked_by, locked_at, unlocked_by, unlocked_at
+	`
+
+	var lock Lock
+
+	if err := s.db.QueryRowContext(
+		ctx,
+		stmt,
+		unlockedBy,
+		time.Now().Format(time.DateTime),
+		id,
+	).Scan(
+		&lock.Id,
+		&lock.UserId,
+		&lock.FromDate,
+		&lock.ToDate,
+		&lock.Reason,
+		&lock.LockedBy,
+		&lock.LockedAt,
+		&lock.UnlockedBy,
+		&lock.UnlockedAt,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			var exists bool
+			if err := s.db.QueryRowContext(ctx, `select exists(select 1 from period_locks where id = ?)`, id).Scan(&exists); err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, ErrAlreadyUnlocked
+			}
+			return nil, ErrLockNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &lock, nil
+}
+
+// List returns locks ordered by newest period first. Unlocked periods are
+// only included when includeUnlocked is set.
+func (s *Store) List(ctx context.Context, includeUnlocked bool) ([]Lock, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, user_id, from_date, to_date, reason, locked_by, locked_at, unlocked_by, unlocked_at
+		from period_locks
+		where ? or unlocked_at is null
+		order by from_date desc, id desc
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, includeUnlocked)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Lock{}
+
+	for rows.Next() {
+		var lock Lock
+		if err := rows.Scan(
+			&lock.Id,
+			&lock.UserId,
+			&lock.FromDate,
+			&lock.ToDate,
+			&lock.Reason,
+			&lock.LockedBy,
+			&lock.LockedAt,
+			&lock.UnlockedBy,
+			&lock.UnlockedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, lock)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// CheckEditable returns an error if time entries of the user on date can no
+// longer be registered, updated or deleted.
+func CheckEditable(ctx context.Context, tx *sql.Tx, userId int64, date string) error {
+	stmt := `
+		select exists (
+			select 1 from timesheets
+			where user_id = ?
+				and status in ('submitted', 'approved')
+				and start_date <= ?
+				and end_date >= ?
+		)
+	`
+
+	var submitted bool
+	if err := tx.QueryRowContext(ctx, stmt, userId, date, date).Scan(&submitted); err != nil {
+		return err
+	}
+
+	if submitted {
+		return ErrTimesheetSubmitted
+	}
+
+	stmt = `
+		select exists (
+			select 1 from period_locks
+			where (user_id is null or user_id = ?)
+				and unlocked_at is null
+				and from_date <= ?
+				and to_date >= ?
+		)
+	`
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, stmt, userId, date, date).Scan(&locked); err != nil {
+		return err
+	}
+
+	if locked {
+		return ErrPeriodLocked
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package projects
+
+// Client is a customer that projects are done for.
+type Client struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	IsActive bool   `json:"isActive"`
+}
+
+// Project groups a category tree. Every category below a project belongs to
+// it, so time entries can be filtered by project and client.
+type Project struct {
+	Id         int64    `json:"id"`
+	ClientId   *int64   `json:"clientId" apiduck:"desc=null for internal projects"`
+	ClientName *string  `json:"clientName"`
+	Name       string   `json:"name"`
+	Code       string   `json:"code"`
+	IsActive   bool     `json:"isActive"`
+	Budget     *float64 `json:"budget" apiduck:"desc=null when the project has no budget"`
+	OwnerId    *int64   `json:"ownerId"`
+	OwnerName  *string  `json:"ownerName"`
+}
+
+type CreateClientInput struct {
+	Name string `json:"name" validate:"required,max=200"`
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type UpdateClientInput struct {
+	Name     string `json:"name" validate:"required,max=200"`
+	Code     string `json:"code" validate:"required,max=20"`
+	IsActive bool   `json:"isActive"`
+}
+
+type CreateProjectInput struct {
+	ClientId *int64   `json:"clientId"`
+	Name     string   `json:"name" validate:"required,max=200"`
+	Code     string   `json:"code" validate:"required,max=20"`
+	Budget   *float64 `json:"budget" validate:"omitempty,gte=0"`
+	OwnerId  *int64   `json:"ownerId"`
+}
+
+type UpdateProjectInput struct {
+	ClientId *int64   `json:"clientId"`
+	Name     string   `json:"name" validate:"required,max=200"`
+	Code     string   `json:"code" validate:"required,max=20"`
+	IsActive bool     `json:"isActive"`
+	Budget   *float64 `json:"budget" validate:"omitempty,gte=0"`
+	OwnerId  *int64   `json:"ownerId"`
+}
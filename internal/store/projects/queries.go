@@ -0,0 +1,359 @@
+package projects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+)
+
+var (
+	ErrDuplicateCode   = errors.New("code is already in use")
+	ErrClientNotFound  = errors.New("client not found")
+	ErrProjectNotFound = errors.New("project not found")
+	ErrOwnerNotFound   = errors.New("owner not found")
+)
+
+func (s *Store) ListClients(ctx context.Context) ([]Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, name, code, is_active
+		from clients
+		order by name, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Client{}
+
+	for rows.Next() {
+		var client Client
+		if err := rows.Scan(
+			&client.Id,
+			&client.Name,
+			&client.Code,
+			&client.IsActive,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, client)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *Store) CreateClient(ctx context.Context, input CreateClientInput) (*Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into clients (name, code)
+		values (?, ?)
+		returning id, is_active
+	`
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Client, error) {
+		client := Client{
+			Name: input.Name,
+			Code: strings.TrimSpace(input.Code),
+		}
+
+		if err := tx.QueryRowContext(ctx, stmt, client.Name, client.Code).Scan(&client.Id, &client.IsActive); err != nil {
+			return nil, uniqueCodeError(err)
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionCreate, audit.EntityClient, client.Id, nil, client); err != nil {
+			return nil, err
+		}
+
+		return &client, nil
+	})
+}
+
+func (s *Store) UpdateClient(ctx context.Context, id int64, input UpdateClientInput) (*Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update clients
+		set name = ?, code = ?, is_active = ?
+		where id = ?
+	`
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Client, error) {
+		before, err := s.getClient(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		client := Client{
+			Id:       id,
+			Name:     input.Name,
+			Code:     strings.TrimSpace(input.Code),
+			IsActive: input.IsActive,
+		}
+
+		if _, err := tx.ExecContext(ctx, stmt, client.Name, client.Code, client.IsActive, id); err != nil {
+			return nil, uniqueCodeError(err)
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityClient, id, before, client); err != nil {
+			return nil, err
+		}
+
+		return &client, nil
+	})
+}
+
+func (s *Store) getClient(ctx context.Context, tx *sql.Tx, id int64) (*Client, error) {
+	stmt := `select id, name, code, is_active from clients where id = ?`
+
+	var client Client
+	if err := tx.QueryRowContext(ctx, stmt, id).Scan(
+		&client.Id,
+		&client.Name,
+		&client.Code,
+		&client.IsActive,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrClientNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &client, nil
+}
+
+// ListProjects returns all projects, or only the projects of clientId when
+// it is set.
+func (s *Store) ListProjects(ctx context.Context, clientId *int64) ([]Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select
+			p.id,
+			p.client_id,
+			c.name,
+			p.name,
+			p.code,
+			p.is_active,
+			p.budget,
+			p.owner_id,
+			u.name
+		from projects p
+		left join clients c on c.id = p.client_id
+		left join users u on u.id = p.owner_id
+		where ? is null or p.client_id = ?
+		order by p.name, p.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, clientId, clientId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Project{}
+
+	for rows.Next() {
+		var project Project
+		if err := rows.Scan(
+			&project.Id,
+			&project.ClientId,
+			&project.ClientName,
+			&project.Name,
+			&project.Code,
+			&project.IsActive,
+			&project.Budget,
+			&project.OwnerId,
+			&project.OwnerName,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *Store) CreateProject(ctx context.Context, input CreateProjectInput) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into projects (client_id, name, code, budget, owner_id)
+		values (?, ?, ?, ?, ?)
+		returning id
+	`
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Project, error) {
+		if err := s.checkReferences(ctx, tx, input.ClientId, input.OwnerId); err != nil {
+			return nil, err
+		}
+
+		var id int64
+		if err := tx.QueryRowContext(
+			ctx,
+			stmt,
+			input.ClientId,
+			input.Name,
+			strings.TrimSpace(input.Code),
+			input.Budget,
+			input.OwnerId,
+		).Scan(&id); err != nil {
+			return nil, uniqueCodeError(err)
+		}
+
+		after, err := s.getProject(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionCreate, audit.EntityProject, id, nil, after); err != nil {
+			return nil, err
+		}
+
+		return after, nil
+	})
+}
+
+func (s *Store) UpdateProject(ctx context.Context, id int64, input UpdateProjectInput) (*Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update projects
+		set client_id = ?, name = ?, code = ?, is_active = ?, budget = ?, owner_id = ?
+		where id = ?
+	`
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Project, error) {
+		before, err := s.getProject(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.checkReferences(ctx, tx, input.ClientId, input.OwnerId); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			stmt,
+			input.ClientId,
+			input.Name,
+			strings.TrimSpace(input.Code),
+			input.IsActive,
+			input.Budget,
+			input.OwnerId,
+			id,
+		); err != nil {
+			return nil, uniqueCodeError(err)
+		}
+
+		after, err := s.getProject(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityProject, id, before, after); err != nil {
+			return nil, err
+		}
+
+		return after, nil
+	})
+}
+
+func (s *Store) getProject(ctx context.Context, tx *sql.Tx, id int64) (*Project, error) {
+	stmt := `
+		select
+			p.id,
+			p.client_id,
+			c.name,
+			p.name,
+			p.code,
+			p.is_active,
+			p.budget,
+			p.owner_id,
+			u.name
+		from projects p
+		left join clients c on c.id = p.client_id
+		left join users u on u.id = p.owner_id
+		where p.id = ?
+	`
+
+	var project Project
+	if err := tx.QueryRowContext(ctx, stmt, id).Scan(
+		&project.Id,
+		&project.ClientId,
+		&project.ClientName,
+		&project.Name,
+		&project.Code,
+		&project.IsActive,
+		&project.Budget,
+		&project.OwnerId,
+		&project.OwnerName,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrProjectNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &project, nil
+}
+
+// checkReferences makes sure the client and owner of a project exist, as
+// foreign keys are not enforced by the database.
+func (s *Store) checkReferences(ctx context.Context, tx *sql.Tx, clientId, ownerId *int64) error {
+	var exists bool
+
+	if clientId != nil {
+		if err := tx.QueryRowContext(ctx, `select exists(select 1 from clients where id = ?)`, *clientId).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrClientNotFound
+		}
+	}
+
+	if ownerId != nil {
+		if err := tx.QueryRowContext(ctx, `select exists(select 1 from users where id = ?)`, *ownerId).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrOwnerNotFound
+		}
+	}
+
+	return nil
+}
+
+func uniqueCodeError(err error) error {
+	if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return ErrDuplicateCode
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package rates
+
+import "sort"
+
+// Rate is an hourly rate for a category, a user or a user on a specific
+// category. It applies from EffectiveFrom until a newer rate for the same
+// scope takes over.
+type Rate struct {
+	Id            int64   `json:"id"`
+	CategoryId    *int64  `json:"categoryId" apiduck:"desc=null when the rate applies to all categories"`
+	UserId        *int64  `json:"userId" apiduck:"desc=null when the rate applies to all users"`
+	Rate          float64 `json:"rate"`
+	EffectiveFrom string  `json:"effectiveFrom"` // yyyy-MM-dd (time.DateOnly)
+}
+
+type CreateRateInput struct {
+	CategoryId    *int64  `json:"categoryId" validate:"required_without=UserId"`
+	UserId        *int64  `json:"userId" validate:"required_without=CategoryId"`
+	Rate          float64 `json:"rate" validate:"gte=0"`
+	EffectiveFrom string  `json:"effectiveFrom" validate:"required,datetime=2006-01-02"`
+}
+
+type scope struct {
+	categoryId int64
+	userId     int64
+}
+
+// Table resolves the rate of a time entry. Rates for a user on a category
+// take precedence over rates for the user, which take precedence over rates
+// for the category. Category rates are inherited by subcategories.
+type Table struct {
+	rates   map[scope][]Rate
+	parents map[int64]*int64
+}
+
+// Lookup returns the rate for userId on categoryId at date, or false if no
+// rate applies.
+func (t *Table) Lookup(userId, categoryId int64, date string) (float64, bool) {
+	for id := &categoryId; id != nil; id = t.parents[*id] {
+		if rate, ok := t.effective(scope{categoryId: *id, userId: userId}, date); ok {
+			return rate, true
+		}
+	}
+
+	if rate, ok := t.effective(scope{userId: userId}, date); ok {
+		return rate, true
+	}
+
+	for id := &categoryId; id != nil; id = t.parents[*id] {
+		if rate, ok := t.effective(scope{categoryId: *id}, date); ok {
+			return rate, true
+		}
+	}
+
+	return 0, false
+}
+
+// effective returns the newest rate in s that is effective at date.
+func (t *Table) effective(s scope, date string) (float64, bool) {
+	list := t.rates[s]
+	i := sort.Search(len(list), func(i int) bool {
+		return list[i].EffectiveFrom > date
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return list[i-1].Rate, true
+}
@@ -0,0 +1,162 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrDuplicateRate  = errors.New("rate already exists for scope and date")
+	ErrRateNotDeleted = errors.New("rate not deleted")
+)
+
+func (s *Store) Create(ctx context.Context, input CreateRateInput) (*Rate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into hourly_rates (category_id, user_id, rate, effective_from)
+		values (?, ?, ?, ?)
+		returning id
+	`
+
+	rate := Rate{
+		CategoryId:    input.CategoryId,
+		UserId:        input.UserId,
+		Rate:          input.Rate,
+		EffectiveFrom: input.EffectiveFrom,
+	}
+
+	if err := s.db.QueryRowContext(
+		ctx,
+		stmt,
+		rate.CategoryId,
+		rate.UserId,
+		rate.Rate,
+		rate.EffectiveFrom,
+	).Scan(&rate.Id); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+			return nil, ErrDuplicateRate
+		default:
+			return nil, err
+		}
+	}
+
+	return &rate, nil
+}
+
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `delete from hourly_rates where id = ?`
+
+	result, err := s.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrRateNotDeleted
+	}
+
+	return nil
+}
+
+func (s *Store) List(ctx context.Context) ([]Rate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select id, category_id, user_id, rate, effective_from
+		from hourly_rates
+		order by effective_from, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Rate{}
+
+	for rows.Next() {
+		var rate Rate
+		if err := rows.Scan(
+			&rate.Id,
+			&rate.CategoryId,
+			&rate.UserId,
+			&rate.Rate,
+			&rate.EffectiveFrom,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Table loads every rate together with the category hierarchy so rates can
+// be resolved for many time entries without a query per entry.
+func (s *Store) Table(ctx context.Context) (*Table, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, `select id, parent_id from categories`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	table := Table{
+		rates:   make(map[scope][]Rate),
+		parents: make(map[int64]*int64),
+	}
+
+	for rows.Next() {
+		var (
+			id       int64
+			parentId *int64
+		)
+		if err := rows.Scan(&id, &parentId); err != nil {
+			return nil, err
+		}
+		table.parents[id] = parentId
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// list is ordered by effective_from, so every scope stays sorted.
+	for _, rate := range list {
+		var key scope
+		if rate.CategoryId != nil {
+			key.categoryId = *rate.CategoryId
+		}
+		if rate.UserId != nil {
+			key.userId = *rate.UserId
+		}
+		table.rates[key] = append(table.rates[key], rate)
+	}
+
+	return &table, nil
+}
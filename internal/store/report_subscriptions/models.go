@@ -0,0 +1,36 @@
+package report_subscriptions
+
+const (
+	ReportSummary     string = "summary"
+	ReportUtilization        = "utilization"
+)
+
+const (
+	SpanDay   string = "day"
+	SpanWeek         = "week"
+	SpanMonth        = "month"
+)
+
+// Subscription mails a report to a recipient on a schedule. Each report
+// covers the span before it is sent, e.g. the previous week.
+type Subscription struct {
+	Id         int64   `json:"id"`
+	Recipient  string  `json:"recipient"`
+	Report     string  `json:"report" apiduck:"desc=summary or utilization"`
+	Span       string  `json:"span" apiduck:"desc=the previous day, week or month is reported"`
+	Filters    string  `json:"filters" apiduck:"desc=query parameters of the report endpoint, e.g. groupBy=user,category&projectId=3"`
+	Schedule   string  `json:"schedule" apiduck:"desc=cron expression, e.g. 0 7 * * 1 for monday at 07:00"`
+	AttachCSV  bool    `json:"attachCsv"`
+	CreatedBy  int64   `json:"createdBy"`
+	CreatedAt  string  `json:"createdAt"`  // yyyy-MM-dd hh:mm:ss (time.DateTime)
+	LastSentAt *string `json:"lastSentAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
+
+type SubscriptionInput struct {
+	Recipient string `json:"recipient" validate:"required,email"`
+	Report    string `json:"report" validate:"required,oneof=summary utilization"`
+	Span      string `json:"span" validate:"required,oneof=day week month"`
+	Filters   string `json:"filters" validate:"max=1000"`
+	Schedule  string `json:"schedule" validate:"required,max=100"`
+	AttachCSV bool   `json:"attachCsv"`
+}
@@ -0,0 +1,195 @@
+package report_subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSubscriptionNotFound   = errors.New("report subscription not found")
+	ErrSubscriptionNotDeleted = errors.New("report subscription not deleted")
+)
+
+const selectSubscription = `
+	select
+		id,
+		recipient,
+		report,
+		span,
+		filters,
+		schedule,
+		attach_csv,
+		created_by,
+		created_at,
+		last_sent_at
+	from report_subscriptions`
+
+func scanSubscription(row interface{ Scan(...any) error }) (*Subscription, error) {
+	var sub Subscription
+	if err := row.Scan(
+		&sub.Id,
+		&sub.Recipient,
+		&sub.Report,
+		&sub.Span,
+		&sub.Filters,
+		&sub.Schedule,
+		&sub.AttachCSV,
+		&sub.CreatedBy,
+		&sub.CreatedAt,
+		&sub.LastSentAt,
+	); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (s *Store) List(ctx context.Context) ([]Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, selectSubscription+` order by recipient, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []Subscription{}
+
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *Store) Get(ctx context.Context, id int64) (*Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	sub, err := scanSubscription(s.db.QueryRowContext(ctx, selectSubscription+` where id = ?`, id))
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrSubscriptionNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *Store) Create(ctx context.Context, createdBy int64, input SubscriptionInput) (*Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into report_subscriptions (
+			recipient, report, span, filters, schedule, attach_csv, created_by, created_at
+		)
+		values (?, ?, ?, ?, ?, ?, ?, ?)
+		returning id
+	`
+
+	sub := Subscription{
+		Recipient: input.Recipient,
+		Report:    input.Report,
+		Span:      input.Span,
+		Filters:   input.Filters,
+		Schedule:  input.Schedule,
+		AttachCSV: input.AttachCSV,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().Format(time.DateTime),
+	}
+
+	if err := s.db.QueryRowContext(ctx, stmt,
+		sub.Recipient,
+		sub.Report,
+		sub.Span,
+		sub.Filters,
+		sub.Schedule,
+		sub.AttachCSV,
+		sub.CreatedBy,
+		sub.CreatedAt,
+	).Scan(&sub.Id); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (s *Store) Update(ctx context.Context, id int64, input SubscriptionInput) (*Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update report_subscriptions
+		set recipient = ?, report = ?, span = ?, filters = ?, schedule = ?, attach_csv = ?
+		where id = ?
+		returning id, recipient, report, span, filters, schedule, attach_csv, created_by, created_at, last_sent_at
+	`
+
+	sub, err := scanSubscription(s.db.QueryRowContext(ctx, stmt,
+		input.Recipient,
+		input.Report,
+		input.Span,
+		input.Filters,
+		input.Schedule,
+		input.AttachCSV,
+		id,
+	))
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrSubscriptionNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `delete from report_subscriptions where id = ?`
+
+	result, err := s.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrSubscriptionNotDeleted
+	}
+
+	return nil
+}
+
+// MarkSent records when the report of a subscription was last mailed.
+func (s *Store) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `update report_subscriptions set last_sent_at = ? where id = ?`
+
+	_, err := s.db.ExecContext(ctx, stmt, at.Format(time.DateTime), id)
+	return err
+}
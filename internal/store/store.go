@@ -5,51 +5,116 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+	"github.com/anvidev/project-time-tracker/internal/store/calendar_feeds"
 	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/closing_days"
 	"github.com/anvidev/project-time-tracker/internal/store/hours"
+	"github.com/anvidev/project-time-tracker/internal/store/import_mappings"
+	"github.com/anvidev/project-time-tracker/internal/store/locks"
+	"github.com/anvidev/project-time-tracker/internal/store/projects"
+	"github.com/anvidev/project-time-tracker/internal/store/rates"
+	"github.com/anvidev/project-time-tracker/internal/store/report_subscriptions"
 	"github.com/anvidev/project-time-tracker/internal/store/sessions"
+	"github.com/anvidev/project-time-tracker/internal/store/suggestion_rules"
+	"github.com/anvidev/project-time-tracker/internal/store/teams"
 	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/store/timesheets"
 	"github.com/anvidev/project-time-tracker/internal/store/users"
+	"github.com/anvidev/project-time-tracker/internal/store/vacation"
+	"github.com/anvidev/project-time-tracker/internal/types"
 )
 
 type Store struct {
-	TimeEntries TimeEntriesStorer
-	Categories  CategoriesStorer
-	Sessions    SessionStorer
-	Users       UserStorer
-	Hours       HourStorer
+	TimeEntries         TimeEntriesStorer
+	Categories          CategoriesStorer
+	Sessions            SessionStorer
+	Users               UserStorer
+	Hours               HourStorer
+	Absences            AbsenceStorer
+	Vacation            VacationStorer
+	ClosingDays         ClosingDayStorer
+	Timesheets          TimesheetStorer
+	Locks               LockStorer
+	Audit               AuditStorer
+	Rates               RateStorer
+	Projects            ProjectStorer
+	Teams               TeamStorer
+	ImportMappings      ImportMappingStorer
+	CalendarFeeds       CalendarFeedStorer
+	SuggestionRules     SuggestionRuleStorer
+	ReportSubscriptions ReportSubscriptionStorer
 }
 
-func NewStore(db *sql.DB) *Store {
+func NewStore(db *sql.DB, holidayRules holidays.RuleSet) *Store {
 	return &Store{
-		TimeEntries: time_entries.NewStore(db),
-		Categories:  categories.NewStore(db),
-		Sessions:    sessions.NewStore(db),
-		Users:       users.NewStore(db),
-		Hours:       hours.NewStore(db),
+		TimeEntries:         time_entries.NewStore(db, holidayRules),
+		Categories:          categories.NewStore(db),
+		Sessions:            sessions.NewStore(db),
+		Users:               users.NewStore(db),
+		Hours:               hours.NewStore(db),
+		Absences:            absences.NewStore(db),
+		Vacation:            vacation.NewStore(db),
+		ClosingDays:         closing_days.NewStore(db),
+		Timesheets:          timesheets.NewStore(db),
+		Locks:               locks.NewStore(db),
+		Audit:               audit.NewStore(db),
+		Rates:               rates.NewStore(db),
+		Projects:            projects.NewStore(db),
+		Teams:               teams.NewStore(db),
+		ImportMappings:      import_mappings.NewStore(db),
+		CalendarFeeds:       calendar_feeds.NewStore(db),
+		SuggestionRules:     suggestion_rules.NewStore(db),
+		ReportSubscriptions: report_subscriptions.NewStore(db),
 	}
 }
 
 type TimeEntriesStorer interface {
 	Register(ctx context.Context, userId int64, input time_entries.RegisterTimeEntryInput) (*time_entries.TimeEntry, error)
+	RegisterMany(ctx context.Context, userId int64, inputs []time_entries.RegisterTimeEntryInput) ([]time_entries.TimeEntry, error)
 	Update(ctx context.Context, userId, id int64, input time_entries.UpdateTimeEntryInput) (*time_entries.TimeEntry, error)
 	Delete(ctx context.Context, id, userId int64) error
+	Restore(ctx context.Context, userId, id int64) (*time_entries.TimeEntry, error)
+	Trash(ctx context.Context, userId int64) ([]time_entries.TimeEntry, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 	SummaryDay(ctx context.Context, userId int64, date time.Time) (*time_entries.SummaryDay, error)
 	SummaryMonth(ctx context.Context, userId int64, month time.Month, year int) (*time_entries.SummaryMonth, error)
 	CategoryTotal(ctx context.Context, categoryId int64) (time.Duration, error)
 	List(ctx context.Context, filters time_entries.Filters) ([]time_entries.TimeEntry, error)
+	Each(ctx context.Context, filters time_entries.Filters, fn func(time_entries.TimeEntry) error) error
+	Totals(ctx context.Context, filters time_entries.Filters, groupBy []string) ([]time_entries.GroupTotals, error)
+	DayTotals(ctx context.Context, userIds []int64, from, to time.Time) ([]time_entries.DayTotals, error)
+	Import(ctx context.Context, entries []time_entries.ImportTimeEntryInput, dryRun bool) ([]time_entries.ImportError, error)
 }
 
 type CategoriesStorer interface {
 	Get(ctx context.Context, id int64) (*categories.Category, error)
 	Create(ctx context.Context, input categories.CreateCategoryInput) (*categories.Category, error)
-	Update(ctx context.Context, id int64, title string) (*categories.Category, error)
+	Update(ctx context.Context, id int64, input categories.UpdateCategoryInput) (*categories.Category, error)
 	ToggleRetire(ctx context.Context, id int64) error
+	SetBillable(ctx context.Context, id int64, billable bool) error
+	SetProject(ctx context.Context, id int64, projectId *int64) error
+	SetBudget(ctx context.Context, id int64, budget *types.Duration) error
+	Budgets(ctx context.Context) ([]categories.Budget, error)
+	SetBudgetAlert(ctx context.Context, id int64, threshold int) error
+	Move(ctx context.Context, id int64, parentId *int64) error
+	Merge(ctx context.Context, id, targetId int64) error
+	Delete(ctx context.Context, id int64) error
+	Access(ctx context.Context, id int64) (*categories.Access, error)
+	SetAccess(ctx context.Context, id int64, input categories.SetAccessInput) (*categories.Access, error)
 	Leafs(ctx context.Context, userId int64) ([]categories.Category, error)
 	Follow(ctx context.Context, id, userId int64) error
 	Unfollow(ctx context.Context, id, userId int64) error
+	FollowSubtree(ctx context.Context, id, userId int64) (int64, error)
+	UnfollowSubtree(ctx context.Context, id, userId int64) (int64, error)
+	SetDefaultFollowed(ctx context.Context, id int64, followed bool) error
 	Tree(ctx context.Context, userId int64) ([]*categories.CategoryTree, error)
+	TreeTotals(ctx context.Context, filters categories.TotalsFilters) ([]*categories.CategoryTree, error)
 	List(ctx context.Context) ([]categories.Category, error)
+	Paths(ctx context.Context) ([]categories.Path, error)
+	Subtree(ctx context.Context, id int64) ([]int64, error)
 }
 
 type SessionStorer interface {
@@ -69,3 +134,89 @@ type HourStorer interface {
 	AllWeekdays(ctx context.Context, userId int64) ([]hours.Weekday, error)
 	UpdateWeekdays(ctx context.Context, userId int64, data []hours.Weekday) error
 }
+
+type AbsenceStorer interface {
+	Register(ctx context.Context, userId int64, input absences.RegisterAbsenceInput) (*absences.Absence, error)
+	Delete(ctx context.Context, id, userId int64) error
+	List(ctx context.Context, userId int64, kind string, from, to time.Time) ([]absences.Absence, error)
+}
+
+type VacationStorer interface {
+	Balance(ctx context.Context, userId int64, date time.Time) (*vacation.Balance, error)
+	SetAllowance(ctx context.Context, userId int64, year int, input vacation.UpdateAllowanceInput) (*vacation.Allowance, error)
+}
+
+type ClosingDayStorer interface {
+	Create(ctx context.Context, input closing_days.CreateClosingDayInput) (*closing_days.ClosingDay, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, from, to time.Time) ([]closing_days.ClosingDay, error)
+}
+
+type TimesheetStorer interface {
+	Submit(ctx context.Context, userId int64, input timesheets.SubmitTimesheetInput) (*timesheets.Timesheet, error)
+	Withdraw(ctx context.Context, userId, id int64) (*timesheets.Timesheet, error)
+	Approve(ctx context.Context, reviewerId, id int64) (*timesheets.Timesheet, error)
+	Reject(ctx context.Context, reviewerId, id int64, comment string) (*timesheets.Timesheet, error)
+	List(ctx context.Context, filters timesheets.ListFilters) ([]timesheets.Timesheet, error)
+}
+
+type LockStorer interface {
+	Create(ctx context.Context, lockedBy int64, input locks.CreateLockInput) (*locks.Lock, error)
+	Unlock(ctx context.Context, unlockedBy, id int64) (*locks.Lock, error)
+	List(ctx context.Context, includeUnlocked bool) ([]locks.Lock, error)
+}
+
+type AuditStorer interface {
+	List(ctx context.Context, filters audit.Filters) ([]audit.Entry, error)
+}
+
+type RateStorer interface {
+	Create(ctx context.Context, input rates.CreateRateInput) (*rates.Rate, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context) ([]rates.Rate, error)
+	Table(ctx context.Context) (*rates.Table, error)
+}
+
+type ProjectStorer interface {
+	ListClients(ctx context.Context) ([]projects.Client, error)
+	CreateClient(ctx context.Context, input projects.CreateClientInput) (*projects.Client, error)
+	UpdateClient(ctx context.Context, id int64, input projects.UpdateClientInput) (*projects.Client, error)
+	ListProjects(ctx context.Context, clientId *int64) ([]projects.Project, error)
+	CreateProject(ctx context.Context, input projects.CreateProjectInput) (*projects.Project, error)
+	UpdateProject(ctx context.Context, id int64, input projects.UpdateProjectInput) (*projects.Project, error)
+}
+
+type TeamStorer interface {
+	List(ctx context.Context) ([]teams.Team, error)
+	Create(ctx context.Context, input teams.CreateTeamInput) (*teams.Team, error)
+	Delete(ctx context.Context, id int64) error
+	SetMembers(ctx context.Context, id int64, userIds []int64) (*teams.Team, error)
+}
+
+type ImportMappingStorer interface {
+	List(ctx context.Context, source string) ([]import_mappings.Mapping, error)
+	Save(ctx context.Context, source string, input []import_mappings.SaveMappingInput) error
+	Delete(ctx context.Context, source string, id int64) error
+}
+
+type CalendarFeedStorer interface {
+	Get(ctx context.Context, userId int64) (*calendar_feeds.Feed, error)
+	Reset(ctx context.Context, userId int64) (*calendar_feeds.Feed, error)
+	Delete(ctx context.Context, userId int64) error
+	UserId(ctx context.Context, token string) (int64, error)
+}
+
+type SuggestionRuleStorer interface {
+	List(ctx context.Context, userId int64, kind string) ([]suggestion_rules.Rule, error)
+	Create(ctx context.Context, userId int64, input suggestion_rules.CreateRuleInput) (*suggestion_rules.Rule, error)
+	Delete(ctx context.Context, userId, id int64) error
+}
+
+type ReportSubscriptionStorer interface {
+	List(ctx context.Context) ([]report_subscriptions.Subscription, error)
+	Get(ctx context.Context, id int64) (*report_subscriptions.Subscription, error)
+	Create(ctx context.Context, createdBy int64, input report_subscriptions.SubscriptionInput) (*report_subscriptions.Subscription, error)
+	Update(ctx context.Context, id int64, input report_subscriptions.SubscriptionInput) (*report_subscriptions.Subscription, error)
+	Delete(ctx context.Context, id int64) error
+	MarkSent(ctx context.Context, id int64, at time.Time) error
+}
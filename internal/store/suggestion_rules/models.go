@@ -0,0 +1,24 @@
+package suggestion_rules
+
+const (
+	KindCalendar string = "calendar"
+	KindGit             = "git"
+)
+
+// Rule suggests a category for calendar events whose title contains the
+// pattern, or for commits in repositories and branches starting with it.
+type Rule struct {
+	Id         int64  `json:"id"`
+	UserId     int64  `json:"userId"`
+	Kind       string `json:"kind" apiduck:"desc=calendar or git"`
+	Pattern    string `json:"pattern" apiduck:"desc=keyword in event titles, or repository/branch prefix for git"`
+	CategoryId int64  `json:"categoryId"`
+	Category   string `json:"category"`
+	CreatedAt  string `json:"createdAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
+
+type CreateRuleInput struct {
+	Kind       string `json:"kind" validate:"required,oneof=calendar git"`
+	Pattern    string `json:"pattern" validate:"required,max=200"`
+	CategoryId int64  `json:"categoryId" validate:"required"`
+}
@@ -0,0 +1,131 @@
+package suggestion_rules
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+)
+
+var (
+	ErrDuplicatePattern = errors.New("a rule with this pattern already exists")
+	ErrRuleNotDeleted   = errors.New("suggestion rule not deleted")
+)
+
+// List returns the rules of a user, all kinds when kind is empty.
+func (s *Store) List(ctx context.Context, userId int64, kind string) ([]Rule, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select
+			r.id,
+			r.user_id,
+			r.kind,
+			r.pattern,
+			r.category_id,
+			coalesce((select title from categories where id = r.category_id), '') as category,
+			r.created_at
+		from suggestion_rules r
+		where r.user_id = ?
+			and (? = '' or r.kind = ?)
+		order by r.kind, r.pattern
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, userId, kind, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []Rule{}
+
+	for rows.Next() {
+		var rule Rule
+		if err := rows.Scan(
+			&rule.Id,
+			&rule.UserId,
+			&rule.Kind,
+			&rule.Pattern,
+			&rule.CategoryId,
+			&rule.Category,
+			&rule.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *Store) Create(ctx context.Context, userId int64, input CreateRuleInput) (*Rule, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if err := categories.CheckVisible(ctx, s.db, userId, input.CategoryId); err != nil {
+		return nil, err
+	}
+
+	rule := Rule{
+		UserId:     userId,
+		Kind:       input.Kind,
+		Pattern:    strings.TrimSpace(input.Pattern),
+		CategoryId: input.CategoryId,
+		CreatedAt:  time.Now().Format(time.DateTime),
+	}
+
+	stmt := `
+		insert into suggestion_rules (user_id, kind, pattern, category_id, created_at)
+		values (?, ?, ?, ?, ?)
+		returning id, (select title from categories where id = ?)
+	`
+
+	if err := s.db.QueryRowContext(
+		ctx,
+		stmt,
+		rule.UserId,
+		rule.Kind,
+		rule.Pattern,
+		rule.CategoryId,
+		rule.CreatedAt,
+		rule.CategoryId,
+	).Scan(&rule.Id, &rule.Category); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+			return nil, ErrDuplicatePattern
+		default:
+			return nil, err
+		}
+	}
+
+	return &rule, nil
+}
+
+func (s *Store) Delete(ctx context.Context, userId, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `delete from suggestion_rules where id = ? and user_id = ?`, id, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected != 1 {
+		return ErrRuleNotDeleted
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package teams
+
+type Team struct {
+	Id      int64   `json:"id"`
+	Name    string  `json:"name"`
+	UserIds []int64 `json:"userIds"`
+}
+
+type CreateTeamInput struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type SetMembersInput struct {
+	UserIds []int64 `json:"userIds" validate:"required"`
+}
@@ -0,0 +1,178 @@
+package teams
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+)
+
+var (
+	ErrDuplicateName   = errors.New("team name is already in use")
+	ErrTeamNotFound    = errors.New("team not found")
+	ErrTeamNotDeleted  = errors.New("team not deleted")
+	ErrUnknownMemberId = errors.New("unknown user id in members")
+)
+
+func (s *Store) List(ctx context.Context) ([]Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select t.id, t.name, tm.user_id
+		from teams t
+		left join team_members tm on tm.team_id = t.id
+		order by t.name, t.id, tm.user_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Team{}
+
+	for rows.Next() {
+		var (
+			team   Team
+			userId *int64
+		)
+		if err := rows.Scan(&team.Id, &team.Name, &userId); err != nil {
+			return nil, err
+		}
+
+		if len(list) == 0 || list[len(list)-1].Id != team.Id {
+			team.UserIds = []int64{}
+			list = append(list, team)
+		}
+
+		if userId != nil {
+			last := &list[len(list)-1]
+			last.UserIds = append(last.UserIds, *userId)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *Store) Create(ctx context.Context, input CreateTeamInput) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		insert into teams (name)
+		values (?)
+		returning id
+	`
+
+	team := Team{
+		Name:    strings.TrimSpace(input.Name),
+		UserIds: []int64{},
+	}
+
+	if err := s.db.QueryRowContext(ctx, stmt, team.Name).Scan(&team.Id); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+			return nil, ErrDuplicateName
+		default:
+			return nil, err
+		}
+	}
+
+	return &team, nil
+}
+
+// Delete removes a team together with its memberships and the category
+// access granted to it.
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `delete from team_members where team_id = ?`, id); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `delete from category_access where team_id = ?`, id); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `delete from teams where id = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected != 1 {
+			return ErrTeamNotDeleted
+		}
+
+		return nil
+	})
+}
+
+// SetMembers replaces the members of a team.
+func (s *Store) SetMembers(ctx context.Context, id int64, userIds []int64) (*Team, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Team, error) {
+		team := Team{Id: id, UserIds: []int64{}}
+
+		if err := tx.QueryRowContext(ctx, `select name from teams where id = ?`, id).Scan(&team.Name); err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return nil, ErrTeamNotFound
+			default:
+				return nil, err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `delete from team_members where team_id = ?`, id); err != nil {
+			return nil, err
+		}
+
+		stmt := `
+			insert or ignore into team_members (team_id, user_id)
+			select ?, id from users where id = ?
+		`
+
+		for _, userId := range userIds {
+			result, err := tx.ExecContext(ctx, stmt, id, userId)
+			if err != nil {
+				return nil, err
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return nil, err
+			}
+
+			var exists bool
+			if affected == 0 {
+				if err := tx.QueryRowContext(ctx, `select exists(select 1 from users where id = ?)`, userId).Scan(&exists); err != nil {
+					return nil, err
+				}
+				if !exists {
+					return nil, ErrUnknownMemberId
+				}
+				continue
+			}
+
+			team.UserIds = append(team.UserIds, userId)
+		}
+
+		return &team, nil
+	})
+}
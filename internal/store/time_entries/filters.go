@@ -3,6 +3,8 @@ package time_entries
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 )
@@ -14,19 +16,26 @@ var (
 	ErrInvalidToDate        = fmt.Errorf("invalid to date")
 	ErrFromDateAfterToDate  = fmt.Errorf("from date cannot be after to date")
 	ErrToDateBeforeFromDate = fmt.Errorf("to date cannot be before from date")
+	ErrInvalidGroupBy       = fmt.Errorf("groupBy must be a comma separated list of user, category, rootCategory, week and month")
 )
 
 type Filters struct {
 	Query      string     `json:"query"`
 	CategoryId []string   `json:"categoryId"`
+	ProjectId  []string   `json:"projectId"`
+	ClientId   []string   `json:"clientId"`
 	UserId     []string   `json:"userId"`
 	FromDate   *time.Time `json:"fromDate"`
 	ToDate     *time.Time `json:"toDate"`
 }
 
 func (f *Filters) Parse(r *http.Request) error {
-	p := r.URL.Query()
+	return f.ParseValues(r.URL.Query())
+}
 
+// ParseValues reads the filters from query parameters, such as those stored
+// with a report subscription.
+func (f *Filters) ParseValues(p url.Values) error {
 	if p.Has("query") && p.Get("query") != "" {
 		f.Query = strings.TrimSpace(p.Get("query"))
 	}
@@ -43,6 +52,30 @@ func (f *Filters) Parse(r *http.Request) error {
 		f.CategoryId = ids
 	}
 
+	if p.Has("projectId") && p.Get("projectId") != "" {
+		idsQuery := strings.Split(p.Get("projectId"), ",")
+		ids := []string{}
+		for _, id := range idsQuery {
+			if id == "" {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		f.ProjectId = ids
+	}
+
+	if p.Has("clientId") && p.Get("clientId") != "" {
+		idsQuery := strings.Split(p.Get("clientId"), ",")
+		ids := []string{}
+		for _, id := range idsQuery {
+			if id == "" {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		f.ClientId = ids
+	}
+
 	if p.Has("userId") && p.Get("userId") != "" {
 		idsQuery := strings.Split(p.Get("userId"), ",")
 		ids := []string{}
@@ -83,3 +116,108 @@ func (f *Filters) Parse(r *http.Request) error {
 
 	return nil
 }
+
+// ParseGroupBy reads a comma separated list of the dimensions to group
+// totals by, in the order the groups are sorted by.
+func ParseGroupBy(value string) ([]string, error) {
+	groupBy := []string{}
+
+	for dimension := range strings.SplitSeq(value, ",") {
+		dimension = strings.TrimSpace(dimension)
+		if dimension == "" {
+			continue
+		}
+		if _, ok := dimensions[dimension]; !ok || slices.Contains(groupBy, dimension) {
+			return nil, ErrInvalidGroupBy
+		}
+		groupBy = append(groupBy, dimension)
+	}
+
+	if len(groupBy) == 0 {
+		return nil, ErrInvalidGroupBy
+	}
+
+	return groupBy, nil
+}
+
+// conditions returns the conditions of the filters to add after a where
+// clause on time_entries te joined with categories c and users u, and their
+// arguments.
+func (filter Filters) conditions() (string, []any) {
+	baseCondition := `
+		and (
+			? = '' or (
+				te.description like '%' || ? || '%'
+				or u.name like '%' || ? || '%'
+				or c.title like '%' || ? || '%'
+			)
+		)`
+
+	args := []any{
+		filter.Query,
+		filter.Query,
+		filter.Query,
+		filter.Query,
+	}
+
+	categoryCondition := ""
+	if len(filter.CategoryId) > 0 {
+		placeholders := make([]string, len(filter.CategoryId))
+		for i, categoryId := range filter.CategoryId {
+			placeholders[i] = "?"
+			args = append(args, categoryId)
+		}
+		categoryCondition = fmt.Sprintf("and te.category_id in (%s)", strings.Join(placeholders, ","))
+	}
+
+	projectCondition := ""
+	if len(filter.ProjectId) > 0 {
+		placeholders := make([]string, len(filter.ProjectId))
+		for i, projectId := range filter.ProjectId {
+			placeholders[i] = "?"
+			args = append(args, projectId)
+		}
+		projectCondition = fmt.Sprintf("and c.project_id in (%s)", strings.Join(placeholders, ","))
+	}
+
+	clientCondition := ""
+	if len(filter.ClientId) > 0 {
+		placeholders := make([]string, len(filter.ClientId))
+		for i, clientId := range filter.ClientId {
+			placeholders[i] = "?"
+			args = append(args, clientId)
+		}
+		clientCondition = fmt.Sprintf("and c.project_id in (select id from projects where client_id in (%s))", strings.Join(placeholders, ","))
+	}
+
+	userCondition := ""
+	if len(filter.UserId) > 0 {
+		placeholders := make([]string, len(filter.UserId))
+		for i, userId := range filter.UserId {
+			placeholders[i] = "?"
+			args = append(args, userId)
+		}
+		userCondition = fmt.Sprintf("and te.user_id in (%s)", strings.Join(placeholders, ","))
+	}
+
+	// the dates are bound with a time of day, which would make a plain
+	// text comparison leave out entries on the from date
+	dateConditions := `
+		and (
+			? is null
+			or te.date >= date(?)
+		)
+		and (
+			? is null
+			or te.date <= date(?)
+		)`
+
+	args = append(args,
+		filter.FromDate,
+		filter.FromDate,
+		filter.ToDate,
+		filter.ToDate,
+	)
+
+	return baseCondition + categoryCondition + projectCondition + clientCondition + userCondition + dateConditions, args
+}
@@ -13,6 +13,10 @@ type TimeEntry struct {
 	Date        string         `json:"date"` // yyyy-MM-dd (time.DateOnly)
 	Duration    types.Duration `json:"duration"`
 	Description string         `json:"description"`
+	Billable    bool           `json:"billable"`
+	Rate        *float64       `json:"rate,omitempty" apiduck:"desc=hourly rate of billable entries, only in admin listings"`
+	Revenue     *float64       `json:"revenue,omitempty" apiduck:"desc=rate multiplied by duration in hours, only in admin listings"`
+	DeletedAt   *string        `json:"deletedAt,omitempty"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
 }
 
 type SummaryDay struct {
@@ -20,6 +24,8 @@ type SummaryDay struct {
 	Weekday     string         `json:"weekday"`
 	TotalHours  types.Duration `json:"totalHours"`
 	MaxHours    types.Duration `json:"maxHours"`
+	IsHoliday   bool           `json:"isHoliday"`
+	HolidayName string         `json:"holidayName"`
 	TimeEntries []TimeEntry    `json:"timeEntries"`
 }
 
@@ -30,14 +36,63 @@ type SummaryMonth struct {
 	Days       []SummaryDay   `json:"days"`
 }
 
+const (
+	GroupByUser         = "user"
+	GroupByCategory     = "category"
+	GroupByRootCategory = "rootCategory"
+	GroupByWeek         = "week"
+	GroupByMonth        = "month"
+)
+
+// GroupTotals sums the time entries of a group. Only the fields of the
+// grouped dimensions are set.
+type GroupTotals struct {
+	UserId         *int64         `json:"userId,omitempty"`
+	User           string         `json:"user,omitempty"`
+	CategoryId     *int64         `json:"categoryId,omitempty"`
+	Category       string         `json:"category,omitempty"`
+	RootCategoryId *int64         `json:"rootCategoryId,omitempty"`
+	RootCategory   string         `json:"rootCategory,omitempty"`
+	Week           string         `json:"week,omitempty"`  // yyyy-Www (ISO week)
+	Month          string         `json:"month,omitempty"` // yyyy-MM
+	TimeSpent      types.Duration `json:"timeSpent"`
+	BillableTime   types.Duration `json:"billableTime"`
+	Entries        int            `json:"entries"`
+}
+
+// DayTotals sums the time entries of a user on a date.
+type DayTotals struct {
+	UserId       int64          `json:"userId"`
+	Date         string         `json:"date"` // yyyy-MM-dd (time.DateOnly)
+	TimeSpent    types.Duration `json:"timeSpent"`
+	BillableTime types.Duration `json:"billableTime"`
+}
+
 type RegisterTimeEntryInput struct {
 	CategoryId  int64          `json:"categoryId"`
 	Date        string         `json:"date"`
 	Duration    types.Duration `json:"duration"`
 	Description string         `json:"description"`
+	Billable    *bool          `json:"billable" apiduck:"desc=defaults to the billable setting of the category"`
+}
+
+type RegisterTimeEntriesInput struct {
+	Entries []RegisterTimeEntryInput `json:"entries" validate:"required,min=1,max=500,dive"`
 }
 
 type UpdateTimeEntryInput struct {
 	Duration    types.Duration `json:"duration"`
 	Description string         `json:"description"`
+	Billable    *bool          `json:"billable" apiduck:"desc=unchanged when omitted"`
+}
+
+type ImportTimeEntryInput struct {
+	Line   int // line in the imported file, used to report errors
+	UserId int64
+	RegisterTimeEntryInput
+}
+
+type ImportError struct {
+	Line int
+	Err  error
 }
@@ -10,24 +10,53 @@ import (
 	"time"
 
 	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
+	"github.com/anvidev/project-time-tracker/internal/store/locks"
 	"github.com/anvidev/project-time-tracker/internal/types"
 )
 
 var (
 	ErrTimeEntryNotDeleted = errors.New("time entry not deleted")
+	ErrTimeEntryNotFound   = errors.New("time entry not found")
 	ErrNoTimeEntriesFound  = errors.New("no rows found")
+	ErrTimesheetSubmitted  = locks.ErrTimesheetSubmitted
+	ErrPeriodLocked        = locks.ErrPeriodLocked
+	ErrTimeEntryNotInTrash = errors.New("time entry is not in the trash")
+	ErrDuplicateTimeEntry  = errors.New("an identical time entry already exists")
+
+	// errImportRolledBack rolls back the transaction of a dry run or an
+	// import with errors.
+	errImportRolledBack = errors.New("import rolled back")
 )
 
 func (s *Store) Register(ctx context.Context, userId int64, input RegisterTimeEntryInput) (*TimeEntry, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*TimeEntry, error) {
+		if err := categories.CheckVisible(ctx, tx, userId, input.CategoryId); err != nil {
+			return nil, err
+		}
+
+		if err := locks.CheckEditable(ctx, tx, userId, input.Date); err != nil {
+			return nil, err
+		}
+
+		return insertEntry(ctx, tx, userId, input)
+	})
+}
+
+// insertEntry inserts and audits a time entry. Callers are responsible for
+// checking that the category is visible and the date is editable.
+func insertEntry(ctx context.Context, tx *sql.Tx, userId int64, input RegisterTimeEntryInput) (*TimeEntry, error) {
 	stmt := `
 		insert into time_entries (
-			category_id, user_id, date, duration, description
+			category_id, user_id, date, duration, duration_seconds, description, billable
 		)
-		values (?, ?, ?, ?, ?)
-		returning id
+		values (?, ?, ?, ?, ?, ?, coalesce(?, (select is_billable from categories where id = ?), 0))
+		returning id, billable
 	`
 
 	entry := TimeEntry{
@@ -38,22 +67,30 @@ func (s *Store) Register(ctx context.Context, userId int64, input RegisterTimeEn
 		Description: input.Description,
 	}
 
-	err := s.db.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx,
 		stmt,
 		entry.CategoryId,
 		entry.UserId,
 		entry.Date,
 		entry.Duration.String(),
+		int64(entry.Duration.Seconds()),
 		entry.Description,
+		input.Billable,
+		entry.CategoryId,
 	).Scan(
 		&entry.Id,
+		&entry.Billable,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := audit.Record(ctx, tx, audit.ActionCreate, audit.EntityTimeEntry, entry.Id, nil, entry); err != nil {
+		return nil, err
+	}
+
 	return &entry, nil
 }
 
@@ -61,35 +98,53 @@ func (s *Store) Update(ctx context.Context, userId, id int64, input UpdateTimeEn
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	stmt := `
-		update time_entries 
-		set duration = ?, description = ?
-		where id = ? and user_id = ?
-		returning id, category_id, user_id, date, duration, description
-	`
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*TimeEntry, error) {
+		before, err := s.getEntry(ctx, tx, userId, id)
+		if err != nil {
+			return nil, err
+		}
 
-	var entry TimeEntry
-	err := s.db.QueryRowContext(
-		ctx,
-		stmt,
-		input.Duration,
-		input.Description,
-		id,
-		userId,
-	).Scan(
-		&entry.Id,
-		&entry.CategoryId,
-		&entry.UserId,
-		&entry.Date,
-		&entry.Duration,
-		&entry.Description,
-	)
+		if err := locks.CheckEditable(ctx, tx, userId, before.Date); err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		stmt := `
+			update time_entries 
+			set duration = ?, duration_seconds = ?, description = ?, billable = coalesce(?, billable)
+			where id = ? and user_id = ?
+			returning id, category_id, user_id, date, duration, description, billable
+		`
 
-	return &entry, nil
+		var entry TimeEntry
+		err = tx.QueryRowContext(
+			ctx,
+			stmt,
+			input.Duration,
+			int64(input.Duration.Seconds()),
+			input.Description,
+			input.Billable,
+			id,
+			userId,
+		).Scan(
+			&entry.Id,
+			&entry.CategoryId,
+			&entry.UserId,
+			&entry.Date,
+			&entry.Duration,
+			&entry.Description,
+			&entry.Billable,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionUpdate, audit.EntityTimeEntry, entry.Id, before, entry); err != nil {
+			return nil, err
+		}
+
+		return &entry, nil
+	})
 }
 
 func (s *Store) SummaryDay(ctx context.Context, userId int64, date time.Time) (*SummaryDay, error) {
@@ -107,9 +162,25 @@ func (s *Store) SummaryDay(ctx context.Context, userId int64, date time.Time) (*
 			return nil, err
 		}
 
+		holiday, isHoliday, err := s.getHoliday(ctx, tx, date)
+		if err != nil {
+			return nil, err
+		}
+
 		day.MaxHours = *weekdayHours
 		day.Weekday = strings.ToLower(date.Weekday().String())
 
+		if isHoliday {
+			day.IsHoliday = true
+			day.HolidayName = holiday.Name
+
+			if holiday.IsDayOff() {
+				day.MaxHours.Duration = 0
+			} else {
+				day.MaxHours.Duration = min(day.MaxHours.Duration, holiday.Hours.Duration)
+			}
+		}
+
 		return day, nil
 	})
 
@@ -172,23 +243,225 @@ func (s *Store) Delete(ctx context.Context, id, userId int64) error {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	stmt := `delete from time_entries where id = ? and user_id = ?`
+	return database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		before, err := s.getEntry(ctx, tx, userId, id)
+		if err != nil {
+			return err
+		}
+
+		if err := locks.CheckEditable(ctx, tx, userId, before.Date); err != nil {
+			return err
+		}
+
+		stmt := `
+			update time_entries
+			set deleted_at = ?
+			where id = ? and user_id = ? and deleted_at is null
+		`
+
+		result, err := tx.ExecContext(ctx, stmt, time.Now().Format(time.DateTime), id, userId)
+		if err != nil {
+			return err
+		}
+
+		affacted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affacted != 1 {
+			return ErrTimeEntryNotDeleted
+		}
+
+		return audit.Record(ctx, tx, audit.ActionDelete, audit.EntityTimeEntry, before.Id, before, nil)
+	})
+}
+
+// Restore moves a deleted time entry out of the trash.
+func (s *Store) Restore(ctx context.Context, userId, id int64) (*TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*TimeEntry, error) {
+		stmt := `
+			update time_entries
+			set deleted_at = null
+			where id = ? and user_id = ? and deleted_at is not null
+			returning id, category_id, user_id, date, duration, description, billable
+		`
+
+		var entry TimeEntry
+		if err := tx.QueryRowContext(ctx, stmt, id, userId).Scan(
+			&entry.Id,
+			&entry.CategoryId,
+			&entry.UserId,
+			&entry.Date,
+			&entry.Duration,
+			&entry.Description,
+			&entry.Billable,
+		); err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return nil, ErrTimeEntryNotInTrash
+			default:
+				return nil, err
+			}
+		}
+
+		if err := locks.CheckEditable(ctx, tx, userId, entry.Date); err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionRestore, audit.EntityTimeEntry, entry.Id, nil, entry); err != nil {
+			return nil, err
+		}
+
+		return &entry, nil
+	})
+}
+
+// Trash returns the deleted time entries of the user, most recently deleted first.
+func (s *Store) Trash(ctx context.Context, userId int64) ([]TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		select
+			te.id,
+			te.category_id,
+			c.title as category,
+			te.user_id,
+			te.date,
+			te.duration,
+			te.description,
+			te.billable,
+			te.deleted_at
+		from time_entries te
+		inner join categories c on c.id = te.category_id
+		where te.user_id = ? and te.deleted_at is not null
+		order by te.deleted_at desc
+	`
 
-	result, err := s.db.ExecContext(ctx, stmt, id, userId)
+	rows, err := s.db.QueryContext(ctx, stmt, userId)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
+
+	entries := []TimeEntry{}
+
+	for rows.Next() {
+		var te TimeEntry
+		if err := rows.Scan(
+			&te.Id,
+			&te.CategoryId,
+			&te.Category,
+			&te.UserId,
+			&te.Date,
+			&te.Duration,
+			&te.Description,
+			&te.Billable,
+			&te.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, te)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Purge permanently deletes time entries that have been in the trash since
+// before the given time and returns the number of purged entries.
+func (s *Store) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var purged int64
+
+	err := database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		stmt := `
+			delete from time_entries
+			where deleted_at is not null and deleted_at < ?
+			returning id, category_id, user_id, date, duration, description, billable, deleted_at
+		`
+
+		rows, err := tx.QueryContext(ctx, stmt, deletedBefore.Format(time.DateTime))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		entries := []TimeEntry{}
+
+		for rows.Next() {
+			var te TimeEntry
+			if err := rows.Scan(
+				&te.Id,
+				&te.CategoryId,
+				&te.UserId,
+				&te.Date,
+				&te.Duration,
+				&te.Description,
+				&te.Billable,
+				&te.DeletedAt,
+			); err != nil {
+				return err
+			}
+			entries = append(entries, te)
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := audit.Record(ctx, tx, audit.ActionPurge, audit.EntityTimeEntry, entry.Id, entry, nil); err != nil {
+				return err
+			}
+		}
+
+		purged = int64(len(entries))
+		return nil
+	})
 
-	affacted, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if affacted != 1 {
-		return ErrTimeEntryNotDeleted
+	return purged, nil
+}
+
+func (s *Store) getEntry(ctx context.Context, tx *sql.Tx, userId, id int64) (*TimeEntry, error) {
+	stmt := `
+		select id, category_id, user_id, date, duration, description, billable
+		from time_entries
+		where id = ? and user_id = ? and deleted_at is null
+	`
+
+	var entry TimeEntry
+	if err := tx.QueryRowContext(ctx, stmt, id, userId).Scan(
+		&entry.Id,
+		&entry.CategoryId,
+		&entry.UserId,
+		&entry.Date,
+		&entry.Duration,
+		&entry.Description,
+		&entry.Billable,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrTimeEntryNotFound
+		default:
+			return nil, err
+		}
 	}
 
-	return nil
+	return &entry, nil
 }
 
 func (s *Store) getWeekdayHours(ctx context.Context, tx *sql.Tx, userId int64, weekday time.Weekday) (*types.Duration, error) {
@@ -206,6 +479,36 @@ func (s *Store) getWeekdayHours(ctx context.Context, tx *sql.Tx, userId int64, w
 	return &hours, nil
 }
 
+// getHoliday looks up date in the public holiday rules and the company
+// closing days. Public holidays take precedence over closing days.
+func (s *Store) getHoliday(ctx context.Context, tx *sql.Tx, date time.Time) (holidays.Holiday, bool, error) {
+	if holiday, ok := holidays.Find(s.holidays, date); ok {
+		return holiday, true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `select date, name, hours from closing_days where date = ?`
+
+	holiday := holidays.Holiday{Kind: holidays.KindClosing}
+
+	if err := tx.QueryRowContext(ctx, stmt, date.Format(time.DateOnly)).Scan(
+		&holiday.Date,
+		&holiday.Name,
+		&holiday.Hours,
+	); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return holidays.Holiday{}, false, nil
+		default:
+			return holidays.Holiday{}, false, err
+		}
+	}
+
+	return holiday, true, nil
+}
+
 func (s *Store) getDailySummary(ctx context.Context, tx *sql.Tx, userId int64, date time.Time) (*SummaryDay, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
@@ -218,9 +521,10 @@ func (s *Store) getDailySummary(ctx context.Context, tx *sql.Tx, userId int64, d
 			te.date,
 			te.duration,
 			te.description,
+			te.billable,
 			(select title from categories where id = te.category_id) as category
 		from time_entries te
-		where user_id = ? and date = ?
+		where user_id = ? and date = ? and deleted_at is null
 		order by id desc
 	`
 
@@ -242,6 +546,7 @@ func (s *Store) getDailySummary(ctx context.Context, tx *sql.Tx, userId int64, d
 			&e.Date,
 			&e.Duration,
 			&e.Description,
+			&e.Billable,
 			&e.Category,
 		)
 		timeEntries = append(timeEntries, e)
@@ -266,46 +571,55 @@ func (s *Store) getDailySummary(ctx context.Context, tx *sql.Tx, userId int64, d
 	return summary, nil
 }
 
+// CategoryTotal returns the time registered on a category and all of its
+// subcategories.
 func (s *Store) CategoryTotal(ctx context.Context, categoryId int64) (time.Duration, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	var total time.Duration
-
 	stmt := `
-			select duration
-			from time_entries
-			where category_id = ? 
-		`
+		with recursive subtree(id) as (
+			select id from categories where id = ?
+			union all
+			select c.id from categories c
+			join subtree st on c.parent_id = st.id
+		)
+		select coalesce(sum(duration_seconds), 0)
+		from time_entries
+		where category_id in (select id from subtree) and deleted_at is null
+	`
 
-	rows, err := s.db.QueryContext(ctx, stmt, categoryId)
-	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return 0, ErrNoTimeEntriesFound
-		default:
-			return 0, err
-		}
+	var seconds int64
+	if err := s.db.QueryRowContext(ctx, stmt, categoryId).Scan(&seconds); err != nil {
+		return 0, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var duration types.Duration
 
-		if err := rows.Scan(&duration); err != nil {
-			return 0, err
-		}
+	return time.Duration(seconds) * time.Second, nil
+}
 
-		fmt.Println(duration.Duration.String())
+func (s *Store) List(ctx context.Context, filter Filters) ([]TimeEntry, error) {
+	entries := []TimeEntry{}
 
-		total += duration.Duration
+	err := s.each(ctx, s.queryTimeout, filter, func(te TimeEntry) error {
+		entries = append(entries, te)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return total, nil
+	return entries, nil
 }
 
-func (s *Store) List(ctx context.Context, filter Filters) ([]TimeEntry, error) {
-	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+// Each calls fn for every time entry matching filter, in the same order as
+// List, without loading all of them into memory. It stops at the first error
+// returned by fn.
+func (s *Store) Each(ctx context.Context, filter Filters, fn func(TimeEntry) error) error {
+	return s.each(ctx, streamTimeout, filter, fn)
+}
+
+func (s *Store) each(ctx context.Context, timeout time.Duration, filter Filters, fn func(TimeEntry) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	baseStmt := `
@@ -317,73 +631,24 @@ func (s *Store) List(ctx context.Context, filter Filters) ([]TimeEntry, error) {
 			u.name as user_name,
 			te.date,
 			te.duration,
-			te.description
+			te.description,
+			te.billable
 		from time_entries te
 		inner join categories c on c.id = te.category_id
 		inner join users u on u.id = te.user_id
-		where (
-			? = '' or (
-				te.description like '%' || ? || '%'
-				or u.name like '%' || ? || '%'
-				or c.title like '%' || ? || '%'
-			)
-		)`
-
-	args := []any{
-		filter.Query,
-		filter.Query,
-		filter.Query,
-		filter.Query,
-	}
-
-	categoryCondition := ""
-	if len(filter.CategoryId) > 0 {
-		placeholders := make([]string, len(filter.CategoryId))
-		for i, categoryId := range filter.CategoryId {
-			placeholders[i] = "?"
-			args = append(args, categoryId)
-		}
-		categoryCondition = fmt.Sprintf("and te.category_id in (%s)", strings.Join(placeholders, ","))
-	}
+		where te.deleted_at is null`
 
-	userCondition := ""
-	if len(filter.UserId) > 0 {
-		placeholders := make([]string, len(filter.UserId))
-		for i, userId := range filter.UserId {
-			placeholders[i] = "?"
-			args = append(args, userId)
-		}
-		userCondition = fmt.Sprintf("and te.user_id in (%s)", strings.Join(placeholders, ","))
-	}
+	conditions, args := filter.conditions()
 
-	remainingConditions := `
-		and (
-			? is null
-			or te.date >= ?
-		)
-		and (
-			? is null
-			or te.date <= ?
-		)
+	stmt := baseStmt + conditions + `
 		order by te.date desc`
 
-	stmt := baseStmt + categoryCondition + userCondition + remainingConditions
-
-	args = append(args,
-		filter.FromDate,
-		filter.FromDate,
-		filter.ToDate,
-		filter.ToDate,
-	)
-
 	rows, err := s.db.QueryContext(ctx, stmt, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	entries := []TimeEntry{}
-
 	for rows.Next() {
 		var te TimeEntry
 
@@ -396,12 +661,319 @@ func (s *Store) List(ctx context.Context, filter Filters) ([]TimeEntry, error) {
 			&te.Date,
 			&te.Duration,
 			&te.Description,
+			&te.Billable,
 		); err != nil {
-			return nil, err
+			return err
 		}
 
-		entries = append(entries, te)
+		if err := fn(te); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Import registers time entries on behalf of their users in a single
+// transaction. Every entry is checked like a regular registration, and
+// entries identical to one registered before the import are rejected so an
+// import can be run again after fixing errors. Nothing is committed when any
+// entry fails or when dryRun is set.
+func (s *Store) Import(ctx context.Context, entries []ImportTimeEntryInput, dryRun bool) ([]ImportError, error) {
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	var importErrors []ImportError
+
+	err := database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		// entries are only compared with those registered before the import,
+		// so repeated identical entries in a file are all imported
+		var lastId int64
+		if err := tx.QueryRowContext(ctx, `select coalesce(max(id), 0) from time_entries`).Scan(&lastId); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			err := s.importEntry(ctx, tx, entry, lastId)
+			switch {
+			case errors.Is(err, categories.ErrCategoryNotFound),
+				errors.Is(err, ErrTimesheetSubmitted),
+				errors.Is(err, ErrPeriodLocked),
+				errors.Is(err, ErrDuplicateTimeEntry):
+				importErrors = append(importErrors, ImportError{Line: entry.Line, Err: err})
+			case err != nil:
+				return err
+			}
+		}
+
+		if dryRun || len(importErrors) > 0 {
+			return errImportRolledBack
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, err
+	}
+
+	return importErrors, nil
+}
+
+func (s *Store) importEntry(ctx context.Context, tx *sql.Tx, entry ImportTimeEntryInput, lastId int64) error {
+	if err := categories.CheckVisible(ctx, tx, entry.UserId, entry.CategoryId); err != nil {
+		return err
+	}
+
+	if err := locks.CheckEditable(ctx, tx, entry.UserId, entry.Date); err != nil {
+		return err
+	}
+
+	stmt := `
+		select exists (
+			select 1 from time_entries
+			where user_id = ?
+				and category_id = ?
+				and date = ?
+				and duration_seconds = ?
+				and description = ?
+				and deleted_at is null
+				and id <= ?
+		)
+	`
+
+	var exists bool
+	if err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		entry.UserId,
+		entry.CategoryId,
+		entry.Date,
+		int64(entry.Duration.Seconds()),
+		entry.Description,
+		lastId,
+	).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return ErrDuplicateTimeEntry
+	}
+
+	_, err := insertEntry(ctx, tx, entry.UserId, entry.RegisterTimeEntryInput)
+	return err
+}
+
+// RegisterMany registers several time entries in one transaction, so either
+// all or none of them are registered. Errors tell which entry failed,
+// counting from 1.
+func (s *Store) RegisterMany(ctx context.Context, userId int64, inputs []RegisterTimeEntryInput) ([]TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	entries := []TimeEntry{}
+
+	err := database.WithTx(ctx, s.db, func(tx *sql.Tx) error {
+		for i, input := range inputs {
+			if err := categories.CheckVisible(ctx, tx, userId, input.CategoryId); err != nil {
+				return fmt.Errorf("entry %d: %w", i+1, err)
+			}
+
+			if err := locks.CheckEditable(ctx, tx, userId, input.Date); err != nil {
+				return fmt.Errorf("entry %d: %w", i+1, err)
+			}
+
+			entry, err := insertEntry(ctx, tx, userId, input)
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, *entry)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return entries, nil
 }
+
+const weekStart = "date(te.date, '-' || ((cast(strftime('%w', te.date) as integer) + 6) % 7) || ' days')"
+
+// dimension is how time entries are grouped by one of the GroupBy values.
+type dimension struct {
+	columns string // selected and grouped by
+	order   string
+	scan    func(*GroupTotals) []any
+}
+
+var dimensions = map[string]dimension{
+	GroupByUser: {
+		columns: "te.user_id, u.name",
+		order:   "u.name",
+		scan:    func(g *GroupTotals) []any { return []any{&g.UserId, &g.User} },
+	},
+	GroupByCategory: {
+		columns: "te.category_id, c.title",
+		order:   "c.title",
+		scan:    func(g *GroupTotals) []any { return []any{&g.CategoryId, &g.Category} },
+	},
+	GroupByRootCategory: {
+		columns: "root.id, root.title",
+		order:   "root.title",
+		scan:    func(g *GroupTotals) []any { return []any{&g.RootCategoryId, &g.RootCategory} },
+	},
+	GroupByWeek: {
+		// the monday of the week, turned into an ISO week after scanning
+		columns: weekStart,
+		order:   weekStart,
+		scan:    func(g *GroupTotals) []any { return []any{&g.Week} },
+	},
+	GroupByMonth: {
+		columns: "substr(te.date, 1, 7)",
+		order:   "substr(te.date, 1, 7)",
+		scan:    func(g *GroupTotals) []any { return []any{&g.Month} },
+	},
+}
+
+// Totals sums the time entries matching filter per group of the groupBy
+// dimensions, as returned by ParseGroupBy.
+func (s *Store) Totals(ctx context.Context, filter Filters, groupBy []string) ([]GroupTotals, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var columns, order []string
+	for _, name := range groupBy {
+		columns = append(columns, dimensions[name].columns)
+		order = append(order, dimensions[name].order)
+	}
+
+	rootJoin := ""
+	if slices.Contains(groupBy, GroupByRootCategory) {
+		rootJoin = `
+		inner join roots r on r.id = te.category_id
+		inner join categories root on root.id = r.root_id`
+	}
+
+	conditions, args := filter.conditions()
+
+	stmt := `
+		with recursive roots(id, root_id) as (
+			select id, id from categories where parent_id is null
+			union all
+			select c.id, r.root_id from categories c
+			join roots r on c.parent_id = r.id
+		)
+		select
+			` + strings.Join(columns, ",\n\t\t\t") + `,
+			coalesce(sum(te.duration_seconds), 0),
+			coalesce(sum(case when te.billable then te.duration_seconds else 0 end), 0),
+			count(*)
+		from time_entries te
+		inner join categories c on c.id = te.category_id
+		inner join users u on u.id = te.user_id` + rootJoin + `
+		where te.deleted_at is null` + conditions + `
+		group by ` + strings.Join(columns, ", ") + `
+		order by ` + strings.Join(order, ", ")
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []GroupTotals{}
+
+	for rows.Next() {
+		var (
+			g                        GroupTotals
+			seconds, billableSeconds int64
+			dest                     []any
+		)
+
+		for _, name := range groupBy {
+			dest = append(dest, dimensions[name].scan(&g)...)
+		}
+		dest = append(dest, &seconds, &billableSeconds, &g.Entries)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if g.Week != "" {
+			monday, err := time.Parse(time.DateOnly, g.Week)
+			if err != nil {
+				return nil, err
+			}
+			year, week := monday.ISOWeek()
+			g.Week = fmt.Sprintf("%d-W%02d", year, week)
+		}
+
+		g.TimeSpent = types.Duration{Duration: time.Duration(seconds) * time.Second}
+		g.BillableTime = types.Duration{Duration: time.Duration(billableSeconds) * time.Second}
+
+		totals = append(totals, g)
+	}
+
+	return totals, rows.Err()
+}
+
+// DayTotals sums the time entries of each of userIds per date between from
+// and to, both included. Dates without entries are left out.
+func (s *Store) DayTotals(ctx context.Context, userIds []int64, from, to time.Time) ([]DayTotals, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	totals := []DayTotals{}
+
+	if len(userIds) == 0 {
+		return totals, nil
+	}
+
+	args := []any{from.Format(time.DateOnly), to.Format(time.DateOnly)}
+	placeholders := make([]string, len(userIds))
+	for i, userId := range userIds {
+		placeholders[i] = "?"
+		args = append(args, userId)
+	}
+
+	stmt := fmt.Sprintf(`
+		select
+			user_id,
+			date,
+			coalesce(sum(duration_seconds), 0),
+			coalesce(sum(case when billable then duration_seconds else 0 end), 0)
+		from time_entries
+		where deleted_at is null
+		and date between ? and ?
+		and user_id in (%s)
+		group by user_id, date
+		order by user_id, date`, strings.Join(placeholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day                      DayTotals
+			seconds, billableSeconds int64
+		)
+
+		if err := rows.Scan(&day.UserId, &day.Date, &seconds, &billableSeconds); err != nil {
+			return nil, err
+		}
+
+		day.TimeSpent = types.Duration{Duration: time.Duration(seconds) * time.Second}
+		day.BillableTime = types.Duration{Duration: time.Duration(billableSeconds) * time.Second}
+
+		totals = append(totals, day)
+	}
+
+	return totals, rows.Err()
+}
@@ -3,16 +3,27 @@ package time_entries
 import (
 	"database/sql"
 	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/holidays"
 )
 
+// streamTimeout bounds queries whose rows are streamed to a client, such as
+// exports, which take longer than regular queries.
+const streamTimeout = 5 * time.Minute
+
+// importTimeout bounds imports, which insert every entry in one transaction.
+const importTimeout = 5 * time.Minute
+
 type Store struct {
 	db           *sql.DB
 	queryTimeout time.Duration
+	holidays     holidays.RuleSet
 }
 
-func NewStore(db *sql.DB) *Store {
+func NewStore(db *sql.DB, holidayRules holidays.RuleSet) *Store {
 	return &Store{
 		db:           db,
 		queryTimeout: 10 * time.Second,
+		holidays:     holidayRules,
 	}
 }
@@ -0,0 +1,67 @@
+package timesheets
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	StatusOpen      string = "open"
+	StatusSubmitted        = "submitted"
+	StatusApproved         = "approved"
+	StatusRejected         = "rejected"
+)
+
+const (
+	PeriodWeek  string = "week"
+	PeriodMonth        = "month"
+)
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+)
+
+type Timesheet struct {
+	Id          int64   `json:"id"`
+	UserId      int64   `json:"userId"`
+	UserName    string  `json:"userName"`
+	Period      string  `json:"period" apiduck:"desc=week or month"`
+	StartDate   string  `json:"startDate"` // yyyy-MM-dd (time.DateOnly)
+	EndDate     string  `json:"endDate"`   // yyyy-MM-dd (time.DateOnly)
+	Status      string  `json:"status" apiduck:"desc=one of open, submitted, approved or rejected"`
+	Comment     string  `json:"comment"`
+	SubmittedAt *string `json:"submittedAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+	ReviewedBy  *int64  `json:"reviewedBy"`
+	ReviewedAt  *string `json:"reviewedAt"` // yyyy-MM-dd hh:mm:ss (time.DateTime)
+}
+
+type SubmitTimesheetInput struct {
+	Period string `json:"period" validate:"required,oneof=week month"`
+	Date   string `json:"date" validate:"required,datetime=2006-01-02" apiduck:"desc=any date within the period"`
+}
+
+type RejectTimesheetInput struct {
+	Comment string `json:"comment" validate:"required,max=1000"`
+}
+
+type ListFilters struct {
+	UserId *int64
+	Status string
+}
+
+// Bounds returns the first and last date of the week (monday to sunday) or
+// month that date falls in.
+func Bounds(period string, date time.Time) (time.Time, time.Time, error) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case PeriodWeek:
+		start := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6), nil
+	case PeriodMonth:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1), nil
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+}
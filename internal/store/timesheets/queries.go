@@ -0,0 +1,233 @@
+package timesheets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/database"
+)
+
+var (
+	ErrTimesheetNotFound = errors.New("timesheet not found")
+	ErrAlreadySubmitted  = errors.New("timesheet is already submitted or approved")
+	ErrInvalidTransition = errors.New("timesheet cannot change to this status")
+)
+
+const selectTimesheet = `
+	select
+		t.id,
+		t.user_id,
+		u.name,
+		t.period,
+		t.start_date,
+		t.end_date,
+		t.status,
+		t.comment,
+		t.submitted_at,
+		t.reviewed_by,
+		t.reviewed_at
+	from timesheets t
+	inner join users u on u.id = t.user_id
+`
+
+func scanTimesheet(row interface{ Scan(...any) error }) (*Timesheet, error) {
+	var t Timesheet
+
+	if err := row.Scan(
+		&t.Id,
+		&t.UserId,
+		&t.UserName,
+		&t.Period,
+		&t.StartDate,
+		&t.EndDate,
+		&t.Status,
+		&t.Comment,
+		&t.SubmittedAt,
+		&t.ReviewedBy,
+		&t.ReviewedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Submit submits the timesheet of the week or month containing the given
+// date. Open and rejected timesheets can be submitted, and the timesheet is
+// created if it does not exist yet.
+func (s *Store) Submit(ctx context.Context, userId int64, input SubmitTimesheetInput) (*Timesheet, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	date, err := time.Parse(time.DateOnly, input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := Bounds(input.Period, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Timesheet, error) {
+		var status string
+
+		stmt := `select status from timesheets where user_id = ? and start_date = ? and end_date = ?`
+
+		err := tx.QueryRowContext(
+			ctx,
+			stmt,
+			userId,
+			start.Format(time.DateOnly),
+			end.Format(time.DateOnly),
+		).Scan(&status)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		if status == StatusSubmitted || status == StatusApproved {
+			return nil, ErrAlreadySubmitted
+		}
+
+		stmt = `
+			insert into timesheets (user_id, period, start_date, end_date, status, submitted_at)
+			values (?, ?, ?, ?, ?, ?)
+			on conflict (user_id, start_date, end_date) do update
+			set status = excluded.status,
+				submitted_at = excluded.submitted_at,
+				reviewed_by = null,
+				reviewed_at = null
+			returning id
+		`
+
+		var id int64
+		if err := tx.QueryRowContext(
+			ctx,
+			stmt,
+			userId,
+			input.Period,
+			start.Format(time.DateOnly),
+			end.Format(time.DateOnly),
+			StatusSubmitted,
+			time.Now().Format(time.DateTime),
+		).Scan(&id); err != nil {
+			return nil, err
+		}
+
+		return scanTimesheet(tx.QueryRowContext(ctx, selectTimesheet+` where t.id = ?`, id))
+	})
+}
+
+// Withdraw moves a submitted timesheet back to open so it can be edited.
+func (s *Store) Withdraw(ctx context.Context, userId, id int64) (*Timesheet, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update timesheets
+		set status = ?, submitted_at = null
+		where id = ? and user_id = ? and status = ?
+	`
+
+	return s.transition(ctx, id, stmt, StatusOpen, id, userId, StatusSubmitted)
+}
+
+func (s *Store) Approve(ctx context.Context, reviewerId, id int64) (*Timesheet, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update timesheets
+		set status = ?, comment = '', reviewed_by = ?, reviewed_at = ?
+		where id = ? and status = ?
+	`
+
+	return s.transition(ctx, id, stmt, StatusApproved, reviewerId, time.Now().Format(time.DateTime), id, StatusSubmitted)
+}
+
+func (s *Store) Reject(ctx context.Context, reviewerId, id int64, comment string) (*Timesheet, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := `
+		update timesheets
+		set status = ?, comment = ?, reviewed_by = ?, reviewed_at = ?
+		where id = ? and status = ?
+	`
+
+	return s.transition(ctx, id, stmt, StatusRejected, comment, reviewerId, time.Now().Format(time.DateTime), id, StatusSubmitted)
+}
+
+// transition runs an update that only matches timesheets in the expected
+// status and returns the updated timesheet.
+func (s *Store) transition(ctx context.Context, id int64, stmt string, args ...any) (*Timesheet, error) {
+	return database.WithTxResult(ctx, s.db, func(tx *sql.Tx) (*Timesheet, error) {
+		result, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		timesheet, err := scanTimesheet(tx.QueryRowContext(ctx, selectTimesheet+` where t.id = ?`, id))
+		if err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return nil, ErrTimesheetNotFound
+			default:
+				return nil, err
+			}
+		}
+
+		if affected != 1 {
+			return nil, ErrInvalidTransition
+		}
+
+		return timesheet, nil
+	})
+}
+
+func (s *Store) List(ctx context.Context, filters ListFilters) ([]Timesheet, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	stmt := selectTimesheet + `
+		where (? is null or t.user_id = ?)
+			and (? = '' or t.status = ?)
+		order by t.start_date desc, t.user_id
+	`
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		stmt,
+		filters.UserId,
+		filters.UserId,
+		filters.Status,
+		filters.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Timesheet{}
+
+	for rows.Next() {
+		t, err := scanTimesheet(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
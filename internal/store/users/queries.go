@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/anvidev/project-time-tracker/internal/database"
+	"github.com/anvidev/project-time-tracker/internal/store/audit"
+	"github.com/anvidev/project-time-tracker/internal/store/categories"
 	"github.com/anvidev/project-time-tracker/internal/types"
 )
 
@@ -43,6 +45,14 @@ func (s *Store) Register(ctx context.Context, input RegisterUserInput) (*User, e
 			return nil, err
 		}
 
+		if err := categories.FollowDefaults(ctx, tx, user.Id); err != nil {
+			return nil, err
+		}
+
+		if err := audit.Record(ctx, tx, audit.ActionCreate, audit.EntityUser, user.Id, nil, user); err != nil {
+			return nil, err
+		}
+
 		return user, err
 	})
 
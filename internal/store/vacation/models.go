@@ -0,0 +1,75 @@
+package vacation
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultAllowanceDays float64 = 25
+	DefaultMaxCarryOver          = 5
+)
+
+// Allowance is the vacation a user is entitled to in a holiday year. Without
+// an explicit allowance the defaults from the Danish holiday act are used.
+type Allowance struct {
+	UserId       int64    `json:"userId"`
+	HolidayYear  int      `json:"holidayYear"`
+	Days         float64  `json:"days"`
+	MaxCarryOver float64  `json:"maxCarryOver"`
+	CarriedOver  *float64 `json:"carriedOver" apiduck:"desc=overrides the calculated carry-over when set"`
+}
+
+type Balance struct {
+	HolidayYear int     `json:"holidayYear" apiduck:"desc=the year the holiday year starts in"`
+	From        string  `json:"from"` // yyyy-MM-dd (time.DateOnly)
+	To          string  `json:"to"`   // yyyy-MM-dd (time.DateOnly)
+	Allowance   float64 `json:"allowance"`
+	Earned      float64 `json:"earned"`
+	CarriedOver float64 `json:"carriedOver"`
+	Used        float64 `json:"used"`
+	Remaining   float64 `json:"remaining"`
+}
+
+type UpdateAllowanceInput struct {
+	Days         float64  `json:"days" validate:"gte=0,lte=50"`
+	MaxCarryOver float64  `json:"maxCarryOver" validate:"gte=0,lte=50"`
+	CarriedOver  *float64 `json:"carriedOver" validate:"omitnil,gte=0,lte=50"`
+}
+
+func defaultAllowance(userId int64, year int) Allowance {
+	return Allowance{
+		UserId:       userId,
+		HolidayYear:  year,
+		Days:         DefaultAllowanceDays,
+		MaxCarryOver: DefaultMaxCarryOver,
+	}
+}
+
+// HolidayYear returns the holiday year that date belongs to. A holiday year
+// runs from 1 September to 31 August and is named after the year it starts in.
+func HolidayYear(date time.Time) int {
+	if date.Month() >= time.September {
+		return date.Year()
+	}
+	return date.Year() - 1
+}
+
+func HolidayYearStart(year int) time.Time {
+	return time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func HolidayYearEnd(year int) time.Time {
+	return time.Date(year+1, time.August, 31, 0, 0, 0, 0, time.UTC)
+}
+
+// accruedMonths returns the number of whole months of the holiday year that
+// have passed at date. Vacation is earned at the end of each month.
+func accruedMonths(year int, date time.Time) int {
+	months := (date.Year()-year)*12 + int(date.Month()) - int(time.September)
+	return max(0, min(12, months))
+}
+
+func round(days float64) float64 {
+	return math.Round(days*100) / 100
+}
@@ -0,0 +1,217 @@
+package vacation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// Balance calculates the vacation balance of the user for the holiday year
+// that date falls in. Unused days from the previous holiday year are carried
+// over up to the allowed maximum, unless an admin has overridden the carry-over.
+func (s *Store) Balance(ctx context.Context, userId int64, date time.Time) (*Balance, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var createdAt string
+	if err := s.db.QueryRowContext(ctx, `select created_at from users where id = ?`, userId).Scan(&createdAt); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrUserNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	firstDate, err := time.Parse(time.DateOnly, createdAt[:len(time.DateOnly)])
+	if err != nil {
+		return nil, err
+	}
+
+	targetYear := HolidayYear(date)
+	firstYear := min(HolidayYear(firstDate), targetYear)
+
+	allowances, err := s.allowances(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.usedDays(ctx, userId, HolidayYearEnd(targetYear))
+	if err != nil {
+		return nil, err
+	}
+
+	var balance Balance
+	var carriedOver float64
+
+	for year := firstYear; year <= targetYear; year++ {
+		allowance, ok := allowances[year]
+		if !ok {
+			allowance = defaultAllowance(userId, year)
+		}
+
+		if allowance.CarriedOver != nil {
+			carriedOver = *allowance.CarriedOver
+		}
+
+		earned := allowance.Days
+		if year == targetYear {
+			earned = allowance.Days / 12 * float64(accruedMonths(year, date))
+		}
+
+		balance = Balance{
+			HolidayYear: year,
+			From:        HolidayYearStart(year).Format(time.DateOnly),
+			To:          HolidayYearEnd(year).Format(time.DateOnly),
+			Allowance:   allowance.Days,
+			Earned:      round(earned),
+			CarriedOver: round(carriedOver),
+			Used:        round(used[year]),
+			Remaining:   round(earned + carriedOver - used[year]),
+		}
+
+		carriedOver = max(0, min(allowance.MaxCarryOver, balance.Remaining))
+	}
+
+	return &balance, nil
+}
+
+func (s *Store) SetAllowance(ctx context.Context, userId int64, year int, input UpdateAllowanceInput) (*Allowance, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `select exists (select 1 from users where id = ?)`, userId).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	stmt := `
+		insert or replace into vacation_allowances (user_id, holiday_year, days, max_carry_over, carried_over)
+		values (?, ?, ?, ?, ?)
+	`
+
+	allowance := Allowance{
+		UserId:       userId,
+		HolidayYear:  year,
+		Days:         input.Days,
+		MaxCarryOver: input.MaxCarryOver,
+		CarriedOver:  input.CarriedOver,
+	}
+
+	if _, err := s.db.ExecContext(
+		ctx,
+		stmt,
+		allowance.UserId,
+		allowance.HolidayYear,
+		allowance.Days,
+		allowance.MaxCarryOver,
+		allowance.CarriedOver,
+	); err != nil {
+		return nil, err
+	}
+
+	return &allowance, nil
+}
+
+func (s *Store) allowances(ctx context.Context, userId int64) (map[int]Allowance, error) {
+	stmt := `
+		select user_id, holiday_year, days, max_carry_over, carried_over
+		from vacation_allowances
+		where user_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allowances := make(map[int]Allowance)
+
+	for rows.Next() {
+		var a Allowance
+		if err := rows.Scan(
+			&a.UserId,
+			&a.HolidayYear,
+			&a.Days,
+			&a.MaxCarryOver,
+			&a.CarriedOver,
+		); err != nil {
+			return nil, err
+		}
+		allowances[a.HolidayYear] = a
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return allowances, nil
+}
+
+// usedDays sums vacation absences per holiday year. An absence counts as a
+// whole day unless its duration is shorter than the hours of that weekday.
+func (s *Store) usedDays(ctx context.Context, userId int64, until time.Time) (map[int]float64, error) {
+	stmt := `
+		select a.date, a.duration, coalesce(uh.hours, '0s')
+		from absences a
+		left join users_hours uh on uh.user_id = a.user_id
+			and uh.weekday = cast(strftime('%w', a.date) as integer)
+		where a.user_id = ?
+			and a.kind = 'vacation'
+			and a.date <= ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, stmt, userId, until.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	used := make(map[int]float64)
+
+	for rows.Next() {
+		var (
+			dateString string
+			duration   types.Duration
+			hours      types.Duration
+		)
+
+		if err := rows.Scan(&dateString, &duration, &hours); err != nil {
+			return nil, err
+		}
+
+		date, err := time.Parse(time.DateOnly, dateString)
+		if err != nil {
+			return nil, err
+		}
+
+		var days float64
+		switch {
+		case hours.Duration == 0:
+			days = 0
+		case duration.Duration == 0 || duration.Duration >= hours.Duration:
+			days = 1
+		default:
+			days = duration.Hours() / hours.Hours()
+		}
+
+		used[HolidayYear(date)] += days
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return used, nil
+}
@@ -0,0 +1,136 @@
+package suggestions
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/gitlog"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+const (
+	// firstCommitTime is the work assumed before the first commit of a
+	// session, as there is no earlier commit to measure from.
+	firstCommitTime = 30 * time.Minute
+
+	// maxCommitGap is the longest gap between two commits that counts as
+	// work. Longer gaps start a new session.
+	maxCommitGap = 2 * time.Hour
+
+	// commitRounding is what commit suggestions are rounded up to.
+	commitRounding = 15 * time.Minute
+
+	maxDescriptionLength = 255
+)
+
+// FromCommits suggests an entry per day and category for the commits of a
+// repository. Commits whose branch, or repository/branch, starts with a git
+// rule get its category; the rest are grouped per day. Merge commits are
+// skipped.
+//
+// The time of a commit is the gap since the previous commit that day, or
+// firstCommitTime for the first commit of a session, so the estimate is
+// only as good as the habit of committing often.
+func FromCommits(repository string, commits []gitlog.Commit, matcher *Matcher) []Suggestion {
+	commits = slices.DeleteFunc(slices.Clone(commits), func(c gitlog.Commit) bool {
+		return c.Merge
+	})
+	slices.SortStableFunc(commits, func(a, b gitlog.Commit) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	type group struct {
+		suggestion Suggestion
+		subjects   []string
+		hashes     []string
+	}
+
+	var (
+		groups   []*group
+		byKey    = map[string]*group{}
+		previous gitlog.Commit
+	)
+
+	for i, commit := range commits {
+		date := commit.Date.Format(time.DateOnly)
+
+		spent := firstCommitTime
+		if i > 0 && previous.Date.Format(time.DateOnly) == date {
+			if gap := commit.Date.Sub(previous.Date); gap <= maxCommitGap {
+				spent = gap
+			}
+		}
+		previous = commit
+
+		texts := []string{commit.Branch}
+		if repository != "" {
+			texts = append(texts, repository+"/"+commit.Branch)
+		}
+		rule, matched := matcher.HasPrefix(texts...)
+
+		key := date
+		if matched {
+			key += fmt.Sprintf("/%d", rule.CategoryId)
+		}
+
+		g, ok := byKey[key]
+		if !ok {
+			g = &group{suggestion: Suggestion{Date: date}}
+			if matched {
+				g.suggestion.apply(rule)
+			}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+
+		g.suggestion.Duration.Duration += spent
+		if commit.Subject != "" && !slices.Contains(g.subjects, commit.Subject) {
+			g.subjects = append(g.subjects, commit.Subject)
+		}
+		g.hashes = append(g.hashes, shortHash(commit.Hash))
+	}
+
+	suggestions := make([]Suggestion, 0, len(groups))
+	for _, g := range groups {
+		s := g.suggestion
+		s.Duration = types.Duration{Duration: roundUp(s.Duration.Duration, commitRounding)}
+		s.Description = truncate(strings.Join(g.subjects, "; "), maxDescriptionLength)
+		s.Source = repository + "@" + g.hashes[0]
+		if len(g.hashes) > 1 {
+			s.Source += ".." + g.hashes[len(g.hashes)-1]
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		return cmp.Compare(a.Date, b.Date)
+	})
+
+	return suggestions
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func roundUp(d, to time.Duration) time.Duration {
+	if rest := d % to; rest != 0 {
+		return d + to - rest
+	}
+	return d
+}
+
+// truncate shortens text to at most max runes, ending it with an ellipsis.
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,100 @@
+// Package suggestions proposes time entries from activity recorded
+// elsewhere, such as calendar events and commits, using the rules of a user
+// to pick categories. Suggestions are only proposals; the user accepts them
+// by registering them in bulk.
+package suggestions
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/ical"
+	"github.com/anvidev/project-time-tracker/internal/store/suggestion_rules"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+type Suggestion struct {
+	Date        string         `json:"date"` // yyyy-MM-dd (time.DateOnly)
+	CategoryId  *int64         `json:"categoryId" apiduck:"desc=null when no rule matched"`
+	Category    string         `json:"category"`
+	RuleId      *int64         `json:"ruleId" apiduck:"desc=the rule that picked the category"`
+	Duration    types.Duration `json:"duration"`
+	Description string         `json:"description"`
+	Source      string         `json:"source" apiduck:"desc=what the suggestion was made from, e.g. the uid of a calendar event"`
+}
+
+// Matcher picks the rule for a text. Rules with longer patterns are more
+// specific and win over shorter ones.
+type Matcher struct {
+	rules []suggestion_rules.Rule
+}
+
+func NewMatcher(rules []suggestion_rules.Rule) *Matcher {
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b suggestion_rules.Rule) int {
+		return cmp.Compare(len(b.Pattern), len(a.Pattern))
+	})
+	return &Matcher{rules: sorted}
+}
+
+// Contains returns the first rule whose pattern is in text, ignoring case.
+func (m *Matcher) Contains(text string) (suggestion_rules.Rule, bool) {
+	text = strings.ToLower(text)
+	for _, rule := range m.rules {
+		if strings.Contains(text, strings.ToLower(rule.Pattern)) {
+			return rule, true
+		}
+	}
+	return suggestion_rules.Rule{}, false
+}
+
+// HasPrefix returns the first rule that is a prefix of one of texts,
+// ignoring case.
+func (m *Matcher) HasPrefix(texts ...string) (suggestion_rules.Rule, bool) {
+	for _, rule := range m.rules {
+		pattern := strings.ToLower(rule.Pattern)
+		for _, text := range texts {
+			if text != "" && strings.HasPrefix(strings.ToLower(text), pattern) {
+				return rule, true
+			}
+		}
+	}
+	return suggestion_rules.Rule{}, false
+}
+
+func (s *Suggestion) apply(rule suggestion_rules.Rule) {
+	s.CategoryId = &rule.CategoryId
+	s.Category = rule.Category
+	s.RuleId = &rule.Id
+}
+
+// FromEvents suggests an entry for every timed event, with the title as
+// description. All-day events, such as holidays or reminders, and events
+// of a day or longer are skipped.
+func FromEvents(events []ical.Event, matcher *Matcher) []Suggestion {
+	suggestions := []Suggestion{}
+
+	for _, event := range events {
+		duration := event.End.Sub(event.Start)
+		if event.AllDay || duration <= 0 || duration >= 24*time.Hour {
+			continue
+		}
+
+		suggestion := Suggestion{
+			Date:        event.Start.Format(time.DateOnly),
+			Duration:    types.Duration{Duration: duration},
+			Description: strings.TrimSpace(event.Summary),
+			Source:      event.UID,
+		}
+
+		if rule, ok := matcher.Contains(event.Summary); ok {
+			suggestion.apply(rule)
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
@@ -0,0 +1,199 @@
+// Package utilization compares the hours users are expected to work with the
+// hours they registered, to find who is under-registering.
+package utilization
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+	"github.com/anvidev/project-time-tracker/internal/store/hours"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+const (
+	PeriodTotal string = "total"
+	PeriodWeek         = "week"
+	PeriodMonth        = "month"
+)
+
+var (
+	ErrUnknownPeriod = errors.New("period must be total, week or month")
+)
+
+type Report struct {
+	UserId          int64          `json:"userId"`
+	UserName        string         `json:"userName"`
+	From            string         `json:"from"` // yyyy-MM-dd (time.DateOnly)
+	To              string         `json:"to"`   // yyyy-MM-dd (time.DateOnly)
+	ExpectedHours   types.Duration `json:"expectedHours" apiduck:"desc=weekday hours less holidays, closing days and absences"`
+	AbsenceHours    types.Duration `json:"absenceHours" apiduck:"desc=expected hours covered by absences"`
+	RegisteredHours types.Duration `json:"registeredHours"`
+	BillableHours   types.Duration `json:"billableHours"`
+	BillableShare   float64        `json:"billableShare" apiduck:"desc=billable hours of registered hours, from 0 to 1"`
+	Utilization     float64        `json:"utilization" apiduck:"desc=registered hours of expected hours, 0 when no hours are expected"`
+	MissingDays     []string       `json:"missingDays" apiduck:"desc=past days with expected hours and no registrations"`
+}
+
+// User is what is known about a user in the reported range.
+type User struct {
+	Id       int64
+	Name     string
+	Weekdays []hours.Weekday
+	Absences []absences.Absence
+	Days     []time_entries.DayTotals
+}
+
+// Calculate reports on a user for each period between from and to, both
+// included. Weeks start on monday, and the first and last period are cut off
+// at from and to. Days from today on are never missing, as they may still be
+// registered. holidayList holds the public holidays and closing days of the
+// range.
+func Calculate(user User, holidayList []holidays.Holiday, from, to, today time.Time, period string) ([]Report, error) {
+	weekdayHours := map[time.Weekday]time.Duration{}
+	for _, weekday := range user.Weekdays {
+		weekdayHours[time.Weekday(weekday.Weekday)] = weekday.Hours.Duration
+	}
+
+	holidayByDate := map[string]holidays.Holiday{}
+	for _, holiday := range holidayList {
+		// public holidays come first and take precedence over closing days
+		if _, ok := holidayByDate[holiday.Date]; !ok || holiday.Kind == holidays.KindPublic {
+			holidayByDate[holiday.Date] = holiday
+		}
+	}
+
+	absenceByDate := map[string][]absences.Absence{}
+	for _, absence := range user.Absences {
+		absenceByDate[absence.Date] = append(absenceByDate[absence.Date], absence)
+	}
+
+	dayByDate := map[string]time_entries.DayTotals{}
+	for _, day := range user.Days {
+		dayByDate[day.Date] = day
+	}
+
+	var (
+		reports []Report
+		current *Report
+	)
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		start, err := periodStart(date, period)
+		if err != nil {
+			return nil, err
+		}
+		if start.Before(from) {
+			start = from
+		}
+
+		if current == nil || current.From != start.Format(time.DateOnly) {
+			reports = append(reports, Report{
+				UserId:      user.Id,
+				UserName:    user.Name,
+				From:        start.Format(time.DateOnly),
+				MissingDays: []string{},
+			})
+			current = &reports[len(reports)-1]
+		}
+
+		key := date.Format(time.DateOnly)
+		current.To = key
+
+		expected := weekdayHours[date.Weekday()]
+		if holiday, ok := holidayByDate[key]; ok {
+			if holiday.IsDayOff() {
+				expected = 0
+			} else {
+				expected = min(expected, holiday.Hours.Duration)
+			}
+		}
+
+		absent := absentHours(absenceByDate[key], expected)
+		expected -= absent
+
+		day := dayByDate[key]
+
+		current.ExpectedHours.Duration += expected
+		current.AbsenceHours.Duration += absent
+		current.RegisteredHours.Duration += day.TimeSpent.Duration
+		current.BillableHours.Duration += day.BillableTime.Duration
+
+		if expected > 0 && day.TimeSpent.Duration == 0 && date.Before(today) {
+			current.MissingDays = append(current.MissingDays, key)
+		}
+	}
+
+	for i := range reports {
+		r := &reports[i]
+		if r.RegisteredHours.Duration > 0 {
+			r.BillableShare = ratio(r.BillableHours.Duration, r.RegisteredHours.Duration)
+		}
+		if r.ExpectedHours.Duration > 0 {
+			r.Utilization = ratio(r.RegisteredHours.Duration, r.ExpectedHours.Duration)
+		}
+	}
+
+	return reports, nil
+}
+
+// absentHours is how much of expected the absences of a day cover. An
+// absence without a duration covers the whole day.
+func absentHours(list []absences.Absence, expected time.Duration) time.Duration {
+	var absent time.Duration
+	for _, absence := range list {
+		if absence.Duration.Duration == 0 {
+			return expected
+		}
+		absent += absence.Duration.Duration
+	}
+	return min(absent, expected)
+}
+
+func periodStart(date time.Time, period string) (time.Time, error) {
+	switch period {
+	case PeriodTotal:
+		return time.Time{}, nil
+	case PeriodWeek:
+		offset := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, -offset), nil
+	case PeriodMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()), nil
+	default:
+		return time.Time{}, ErrUnknownPeriod
+	}
+}
+
+// ParsePeriod reads the period to split reports by, defaulting to the
+// whole range.
+func ParsePeriod(value string) (string, error) {
+	switch value {
+	case "":
+		return PeriodTotal, nil
+	case PeriodTotal, PeriodWeek, PeriodMonth:
+		return value, nil
+	default:
+		return "", ErrUnknownPeriod
+	}
+}
+
+// ratio divides a by b, rounded to three decimals.
+func ratio(a, b time.Duration) float64 {
+	return math.Round(float64(a)/float64(b)*1000) / 1000
+}
+
+// Sort orders reports by period and then by user name.
+func Sort(reports []Report) {
+	slices.SortStableFunc(reports, func(a, b Report) int {
+		return cmp.Or(
+			strings.Compare(a.From, b.From),
+			strings.Compare(a.UserName, b.UserName),
+		)
+	})
+}
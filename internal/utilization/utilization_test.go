@@ -0,0 +1,199 @@
+package utilization
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/anvidev/project-time-tracker/internal/holidays"
+	"github.com/anvidev/project-time-tracker/internal/store/absences"
+	"github.com/anvidev/project-time-tracker/internal/store/hours"
+	"github.com/anvidev/project-time-tracker/internal/store/time_entries"
+	"github.com/anvidev/project-time-tracker/internal/types"
+)
+
+// user works 7.5 hours monday to thursday and 7 hours on friday.
+var user = User{
+	Id:   1,
+	Name: "Test",
+	Weekdays: []hours.Weekday{
+		{Weekday: 1, Hours: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+		{Weekday: 2, Hours: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+		{Weekday: 3, Hours: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+		{Weekday: 4, Hours: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+		{Weekday: 5, Hours: types.Duration{Duration: 7 * time.Hour}},
+	},
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		absences []absences.Absence
+		days     []time_entries.DayTotals
+		holidays []holidays.Holiday
+		from, to string
+		period   string
+
+		wantPeriods     []string // from/to of each report
+		wantExpected    []time.Duration
+		wantAbsence     []time.Duration
+		wantUtilization []float64
+		wantMissing     []string
+	}{
+		{
+			name: "registered part of the week",
+			days: []time_entries.DayTotals{
+				{Date: "2026-10-12", TimeSpent: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+				{Date: "2026-10-13", TimeSpent: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+			},
+			from:            "2026-10-12",
+			to:              "2026-10-18",
+			period:          PeriodTotal,
+			wantPeriods:     []string{"2026-10-12/2026-10-18"},
+			wantExpected:    []time.Duration{37 * time.Hour},
+			wantAbsence:     []time.Duration{0},
+			wantUtilization: []float64{0.405},
+			wantMissing:     []string{"2026-10-14", "2026-10-15", "2026-10-16"},
+		},
+		{
+			name: "holidays, closing days and absences",
+			absences: []absences.Absence{
+				{Date: "2026-10-14", Kind: "sick"},
+				{Date: "2026-10-15", Kind: "other", Duration: types.Duration{Duration: 2 * time.Hour}},
+			},
+			days: []time_entries.DayTotals{
+				{Date: "2026-10-12", TimeSpent: types.Duration{Duration: 7*time.Hour + 30*time.Minute}},
+			},
+			holidays: []holidays.Holiday{
+				{Date: "2026-10-13", Name: "Public", Kind: holidays.KindPublic},
+				{Date: "2026-10-16", Name: "Closing", Kind: holidays.KindClosing, Hours: &types.Duration{Duration: 4 * time.Hour}},
+			},
+			from:   "2026-10-12",
+			to:     "2026-10-18",
+			period: PeriodTotal,
+			// 7.5 on monday, 5.5 on thursday and 4 on friday
+			wantPeriods:     []string{"2026-10-12/2026-10-18"},
+			wantExpected:    []time.Duration{17 * time.Hour},
+			wantAbsence:     []time.Duration{9*time.Hour + 30*time.Minute},
+			wantUtilization: []float64{0.441},
+			wantMissing:     []string{"2026-10-15", "2026-10-16"},
+		},
+		{
+			name: "public holiday wins over a closing day",
+			holidays: []holidays.Holiday{
+				{Date: "2026-10-12", Name: "Public", Kind: holidays.KindPublic},
+				{Date: "2026-10-12", Name: "Closing", Kind: holidays.KindClosing, Hours: &types.Duration{Duration: 4 * time.Hour}},
+			},
+			from:            "2026-10-12",
+			to:              "2026-10-12",
+			period:          PeriodTotal,
+			wantPeriods:     []string{"2026-10-12/2026-10-12"},
+			wantExpected:    []time.Duration{0},
+			wantAbsence:     []time.Duration{0},
+			wantUtilization: []float64{0},
+			wantMissing:     []string{},
+		},
+		{
+			name:            "weeks are cut off at from and to",
+			from:            "2026-10-16",
+			to:              "2026-10-20",
+			period:          PeriodWeek,
+			wantPeriods:     []string{"2026-10-16/2026-10-18", "2026-10-19/2026-10-20"},
+			wantExpected:    []time.Duration{7 * time.Hour, 15 * time.Hour},
+			wantAbsence:     []time.Duration{0, 0},
+			wantUtilization: []float64{0, 0},
+			wantMissing:     []string{"2026-10-16"},
+		},
+		{
+			name:            "months",
+			from:            "2026-09-30",
+			to:              "2026-10-01",
+			period:          PeriodMonth,
+			wantPeriods:     []string{"2026-09-30/2026-09-30", "2026-10-01/2026-10-01"},
+			wantExpected:    []time.Duration{7*time.Hour + 30*time.Minute, 7*time.Hour + 30*time.Minute},
+			wantAbsence:     []time.Duration{0, 0},
+			wantUtilization: []float64{0, 0},
+			wantMissing:     []string{"2026-09-30", "2026-10-01"},
+		},
+	}
+
+	// days from today on are never missing
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := user
+			u.Absences = tt.absences
+			u.Days = tt.days
+
+			from, _ := time.Parse(time.DateOnly, tt.from)
+			to, _ := time.Parse(time.DateOnly, tt.to)
+
+			reports, err := Calculate(u, tt.holidays, from, to, today, tt.period)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				periods     []string
+				expected    []time.Duration
+				absence     []time.Duration
+				utilization []float64
+				missing     = []string{}
+			)
+			for _, r := range reports {
+				periods = append(periods, r.From+"/"+r.To)
+				expected = append(expected, r.ExpectedHours.Duration)
+				absence = append(absence, r.AbsenceHours.Duration)
+				utilization = append(utilization, r.Utilization)
+				missing = append(missing, r.MissingDays...)
+			}
+
+			if !slices.Equal(periods, tt.wantPeriods) {
+				t.Errorf("periods = %v, want %v", periods, tt.wantPeriods)
+			}
+			if !slices.Equal(expected, tt.wantExpected) {
+				t.Errorf("expected hours = %v, want %v", expected, tt.wantExpected)
+			}
+			if !slices.Equal(absence, tt.wantAbsence) {
+				t.Errorf("absence hours = %v, want %v", absence, tt.wantAbsence)
+			}
+			if !slices.Equal(utilization, tt.wantUtilization) {
+				t.Errorf("utilization = %v, want %v", utilization, tt.wantUtilization)
+			}
+			if !slices.Equal(missing, tt.wantMissing) {
+				t.Errorf("missing days = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCalculateBillableShare(t *testing.T) {
+	u := user
+	u.Days = []time_entries.DayTotals{
+		{Date: "2026-10-12", TimeSpent: types.Duration{Duration: 6 * time.Hour}, BillableTime: types.Duration{Duration: 4 * time.Hour}},
+		{Date: "2026-10-13", TimeSpent: types.Duration{Duration: 2 * time.Hour}},
+	}
+
+	day := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	reports, err := Calculate(u, nil, day, day.AddDate(0, 0, 1), day, PeriodTotal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := reports[0].BillableShare; got != 0.5 {
+		t.Errorf("BillableShare = %v, want 0.5", got)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	for value, want := range map[string]string{"": PeriodTotal, "total": PeriodTotal, "week": PeriodWeek, "month": PeriodMonth} {
+		if got, err := ParsePeriod(value); got != want || err != nil {
+			t.Errorf("ParsePeriod(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := ParsePeriod("year"); err != ErrUnknownPeriod {
+		t.Errorf("ParsePeriod(year) error = %v, want %v", err, ErrUnknownPeriod)
+	}
+}